@@ -4,8 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"os"
 	"reflect"
+	"strings"
 	"time"
 )
 
@@ -79,8 +79,13 @@ func (h *Handler) Fail(msg string, args ...any) {
 	// want that behavior.
 	r := recover()
 	if r == nil {
-		for _, lm := range h.logMessages {
-			lm.Print(os.Stderr)
+		h.reportUnasserted()
+		root := h.root()
+		root.m.Lock()
+		shadowCleanup := root.shadowCleanup
+		root.m.Unlock()
+		if !shadowCleanup {
+			h.printShadow()
 		}
 
 		h.t.Fatalf(msg, args...)
@@ -92,20 +97,42 @@ func (h *Handler) Fail(msg string, args ...any) {
 // AssertEmpty asserts that all log messages have now been accounted
 // for and there is nothing left.
 //
+// Which levels need to be accounted for can be configured with
+// SetEmptyPolicy; see [EmptyPolicy].
+//
 // A call to this method will be automatically deferred through the
 // testing system if you use New(), but you can also use New
 func (h *Handler) AssertEmpty() {
 	h.t.Helper()
-	h = h.root()
-	h.m.Lock()
-	defer h.m.Unlock()
+	root := h.root()
+	root.m.Lock()
+	policy := root.emptyPolicy
+	count := 0
+	reported := []LogMessage{}
+	for _, lm := range root.logMessages {
+		switch {
+		case policy.mustAssert(lm.Level):
+			count++
+		case policy.report(lm.Level):
+			reported = append(reported, lm.clone())
+		}
+	}
+	dropped := root.droppedMatching(policy.mustAssert)
+	root.m.Unlock()
 
-	if len(h.logMessages) == 0 {
+	if count == 0 && dropped == 0 {
+		if len(reported) > 0 {
+			h.report(policy.reportTitle(len(reported)), reported)
+		}
 		return
 	}
 
-	h.Fail("%d unasserted log message(s); see printout above",
-		len(h.logMessages))
+	if dropped > 0 {
+		h.Fail("%d unasserted log message(s), and %d more dropped by the capacity limit; see printout above",
+			count, dropped)
+		return
+	}
+	h.Fail("%d unasserted log message(s); see printout above", count)
 }
 
 // AssertSomeMessage asserts that some logging events were recorded
@@ -136,12 +163,20 @@ func (h *Handler) AssertMessage(msg string) {
 
 // AssertPrecise takes a LogMessageMatch and asserts the first log
 // message that matches it.
+//
+// The LogMessageMatch is checked with [LogMessageMatch.Validate]
+// first. If nothing matches and some attribute matcher could not
+// compare a message's attribute, the failure will say so.
 func (h *Handler) AssertPrecise(lmm LogMessageMatch) {
 	h.t.Helper()
-	matches := h.Assert(trueOnlyOnce(func(lm LogMessage) bool {
-		return lmm.Matches(lm)
-	}))
+	h.validate(lmm)
+	var invalid error
+	matches := h.Assert(trueOnlyOnce(checkedMatch(lmm, &invalid)))
 	if matches == 0 {
+		if invalid != nil {
+			h.Fail("No logs matching filter were found: %s", invalid)
+			return
+		}
 		h.Fail("No logs matching filter were found")
 	}
 }
@@ -149,13 +184,17 @@ func (h *Handler) AssertPrecise(lmm LogMessageMatch) {
 // AssertSomePrecise asserts all the messages in the log that match
 // the LogMessageMatch criteria. The return value is th enumber of
 // matched messages if there were any. (If there aren't any this fails
-// the test.)
+// the test.) Invalid matchers are reported as with AssertPrecise.
 func (h *Handler) AssertSomePrecise(lmm LogMessageMatch) int {
 	h.t.Helper()
-	matches := h.Assert(func(lm LogMessage) bool {
-		return lmm.Matches(lm)
-	})
+	h.validate(lmm)
+	var invalid error
+	matches := h.Assert(checkedMatch(lmm, &invalid))
 	if matches == 0 {
+		if invalid != nil {
+			h.Fail("No logs matching filter %#v were found: %s", lmm, invalid)
+			return 0
+		}
 		h.Fail("No logs matching filter %#v were found", lmm)
 	}
 	return matches
@@ -176,9 +215,10 @@ func (h *Handler) AssertSomePrecise(lmm LogMessageMatch) int {
 // group, it will be keyed by "request.url". If that is also in a
 // "webserver" group, the key will be "webserver.request.url". Any
 // dots in the keys themselves will be backslash encoded, so a
-// top-level key called "a.b" will be "a\.b" in this map.
+// top-level key called "a.b" will be "a\.b" in this map. [Key] will
+// build these keys for you.
 //
-// The value is a matcher on the attribute, which may be one of three
+// The value is a matcher on the attribute, which may be one of four
 // things.
 //
 // It can be a function "func (slog.Value) bool", which will be passed
@@ -193,48 +233,106 @@ func (h *Handler) AssertSomePrecise(lmm LogMessageMatch) int {
 //
 // It can be a concrete value, in which case it must be equal to the
 // value contained in the attribute. Type-appropriate equality is
-// used, e.g., time.Time's are compared via time.Equal.
+// used, e.g., time.Time's are compared via time.Equal. Any Go integer
+// or float type may be used against KindInt64, KindUint64, and
+// KindFloat64 values, and is compared by numeric value: int32(5)
+// matches slog.Uint64("n", 5) and 5.0 matches slog.Int("n", 5), but
+// -1 never matches a KindUint64 and 5.5 never matches a KindInt64.
 //
 // Any other value will result in an error being returned when used to
-// match.
+// match; see [LogMessageMatch.Validate] and
+// [LogMessageMatch.MatchError].
+//
+// It can be a [ValueMatcher]. For partial matching of structs,
+// slices, and maps held in KindAny attributes, see [Fields],
+// [NonZeroFields], [ContainsElements], [ElementsInAnyOrder], and
+// [MapSubset]. For attributes holding errors, see [ErrorIs],
+// [ErrorAs], [ErrorMessage], and [ErrorChainContains].
+//
+// It can be a [GroupMatch], or a nested map[string]any, to match the
+// contents of the group with that key as a whole, with its own
+// AllAttrsMatch setting. See [Group] and [ExactGroup].
 //
 // AllAttrsMatch indicate whether the Attrs map must contain matches
 // for all attributes in the match. If true, and there are unmatched
-// attribtues in the log message, the match will fail. If false, extra
+// attribtues in the log message, the match will fail. Attributes in a
+// group matched by a GroupMatch are accounted for by that
+// GroupMatch. If false, extra
 // attributes in the log message won't fail the match.
+//
+// StrictKinds turns off the numeric comparison described above for
+// the Attrs, so that a concrete numeric matcher must be exactly
+// int64, uint64, or float64 to match a KindInt64, KindUint64, or
+// KindFloat64 value respectively, and anything else is a type error.
+// Use this if the distinction between logging a signed and unsigned
+// value, or an integer and a float, matters to you.
+//
+// Context is a map of names of context extractors registered with
+// [Handler.ExtractContext] to matchers for the values they extracted.
+// Each value is matched according to the Kind slog.AnyValue gives
+// it, following the same rules as Attrs, so an int extracted value is
+// matched as a KindInt64. A message must have a value from each named
+// extractor to match.
 type LogMessageMatch struct {
 	Message       string
 	Level         slog.Level
 	Attrs         map[string]any
 	AllAttrsMatch bool
+	StrictKinds   bool
+	Context       map[string]any
 }
 
 // Matches returnes true if the provided LogMessage satisfies
-// LogMessageMatch.
+// LogMessageMatch. See [LogMessageMatch.MatchError] to find out why
+// it does not.
+//
+// Matches does not allocate to find that a message does not match,
+// unless a matcher does.
 func (lmm LogMessageMatch) Matches(lm LogMessage) bool {
-	if lmm.Message != lm.Message {
-		return false
-	}
-	if lmm.Level != LevelDontCare && lmm.Level != lm.Level {
-		return false
-	}
+	return lmm.matchesHeader(lm) && lmm.matchValues(lm, false) == nil
+}
 
-	for key, matcher := range lmm.Attrs {
-		val, haveVal := lm.Attrs[key]
-		if !haveVal {
-			// mandatory attribute missing
-			return false
+// matchesHeader returns whether the message and level match.
+func (lmm LogMessageMatch) matchesHeader(lm LogMessage) bool {
+	return lmm.Message == lm.Message &&
+		(lmm.Level == LevelDontCare || lmm.Level == lm.Level)
+}
+
+// String returns a human-readable description of the match, for use
+// in test failure messages.
+func (lmm LogMessageMatch) String() string {
+	desc := &strings.Builder{}
+	fmt.Fprintf(desc, "message %q", lmm.Message)
+	if lmm.Level != LevelDontCare {
+		fmt.Fprintf(desc, " level %s", lmm.Level)
+	}
+	if len(lmm.Attrs) > 0 {
+		desc.WriteString(" attrs {")
+		for idx, key := range sortedKeys(lmm.Attrs) {
+			if idx > 0 {
+				desc.WriteString(", ")
+			}
+			fmt.Fprintf(desc, "%s: %#v", key, lmm.Attrs[key])
 		}
-		if matchAttr(matcher, val) != nil {
-			return false
+		desc.WriteString("}")
+	}
+	if len(lmm.Context) > 0 {
+		desc.WriteString(" context {")
+		for idx, name := range sortedKeys(lmm.Context) {
+			if idx > 0 {
+				desc.WriteString(", ")
+			}
+			fmt.Fprintf(desc, "%s: %#v", name, lmm.Context[name])
 		}
+		desc.WriteString("}")
 	}
-
-	if lmm.AllAttrsMatch && len(lmm.Attrs) != len(lm.Attrs) {
-		return false
+	if lmm.AllAttrsMatch {
+		desc.WriteString(" (all attrs)")
 	}
-
-	return true
+	if lmm.StrictKinds {
+		desc.WriteString(" (strict kinds)")
+	}
+	return desc.String()
 }
 
 // Unasserted returns all the log messages that are currently
@@ -250,7 +348,21 @@ func (lmm LogMessageMatch) Matches(lm LogMessage) bool {
 // examination.
 //
 // However, sometimes you just need to check the messages with code.
+//
+// If the Handler has a capacity limit, this will not include any
+// messages that have been dropped; see [Handler.UnassertedAndDropped].
 func (h *Handler) Unasserted() []LogMessage {
+	h.t.Helper()
+	msgs, _ := h.UnassertedAndDropped()
+	return msgs
+}
+
+// UnassertedAndDropped returns a copy of the currently unasserted
+// messages, as Unasserted does, along with the number of messages
+// that have been dropped due to the capacity limit set with
+// [Handler.SetCapacity], as Dropped does. Both are taken at the same
+// time, so no message is counted in neither or both.
+func (h *Handler) UnassertedAndDropped() ([]LogMessage, int) {
 	h.t.Helper()
 	msgs := []LogMessage{}
 	root := h.root()
@@ -260,7 +372,7 @@ func (h *Handler) Unasserted() []LogMessage {
 	for _, msg := range root.logMessages {
 		msgs = append(msgs, msg.clone())
 	}
-	return msgs
+	return msgs, root.droppedMatching(func(slog.Level) bool { return true })
 }
 
 // Reset will simply empty out the log entirely. This can be used in
@@ -271,6 +383,7 @@ func (h *Handler) Reset() {
 	root := h.root()
 	root.m.Lock()
 	root.logMessages = nil
+	root.dropped = map[slog.Level]int{}
 	root.m.Unlock()
 }
 
@@ -278,6 +391,18 @@ func (h *Handler) Reset() {
 var errNoMatch = errors.New("does not match")
 
 func matchAttr(matcher any, val slog.Value) error {
+	return matchAttrKinds(matcher, val, false)
+}
+
+// matchAttrKinds is matchAttr, with control over whether numeric
+// matchers are compared by value or must be exactly the Go type of
+// the value's Kind. See LogMessageMatch.StrictKinds.
+func matchAttrKinds(matcher any, val slog.Value, strictKinds bool) error {
+	valueMatcher, isValueMatcher := matcher.(ValueMatcher)
+	if isValueMatcher {
+		return valueMatcher.MatchValue(val)
+	}
+
 	matchLogValuer, isLogValuer := matcher.(slog.LogValuer)
 	if isLogValuer {
 		matchVal := matchLogValuer.LogValue()
@@ -287,6 +412,19 @@ func matchAttr(matcher any, val slog.Value) error {
 		return nil
 	}
 
+	if !strictKinds {
+		num, isNum := asNumber(matcher)
+		if isNum {
+			equal, applicable := matchNumeric(num, val)
+			if applicable {
+				if !equal {
+					return errNoMatch
+				}
+				return nil
+			}
+		}
+	}
+
 	switch val.Kind() {
 	case slog.KindAny:
 		switch match := matcher.(type) {
@@ -301,13 +439,18 @@ func matchAttr(matcher any, val slog.Value) error {
 			}
 			return errNoMatch
 		case any:
+			if isFuncMatcher(match) {
+				// func values are never DeepEqual, so this
+				// is a func(T) bool for some other T.
+				return fmt.Errorf("matcher of type %T cannot compare KindAny", matcher)
+			}
 			if reflect.DeepEqual(match, val.Any()) {
 				return nil
 			}
 			return errNoMatch
 		default:
 			// this can't happen but the compiler can't prove it.
-			return fmt.Errorf("invalid type for comparing KindAny: %T", matcher)
+			return fmt.Errorf("matcher of type %T cannot compare KindAny", matcher)
 		}
 
 	case slog.KindBool:
@@ -328,7 +471,7 @@ func matchAttr(matcher any, val slog.Value) error {
 			}
 			return errNoMatch
 		default:
-			return fmt.Errorf("invalid type for comparing KindBool: %T", matcher)
+			return fmt.Errorf("matcher of type %T cannot compare KindBool", matcher)
 		}
 
 	case slog.KindDuration:
@@ -349,7 +492,7 @@ func matchAttr(matcher any, val slog.Value) error {
 			}
 			return errNoMatch
 		default:
-			return fmt.Errorf("invalid type for comparing KindDuration: %T", matcher)
+			return fmt.Errorf("matcher of type %T cannot compare KindDuration", matcher)
 		}
 
 	case slog.KindFloat64:
@@ -369,13 +512,8 @@ func matchAttr(matcher any, val slog.Value) error {
 				return nil
 			}
 			return errNoMatch
-		case int:
-			if float64(match) == val.Float64() {
-				return nil
-			}
-			return errNoMatch
 		default:
-			return fmt.Errorf("invalid type for comparing KindFloat64: %T", matcher)
+			return fmt.Errorf("matcher of type %T cannot compare KindFloat64", matcher)
 		}
 
 	case slog.KindInt64:
@@ -395,13 +533,8 @@ func matchAttr(matcher any, val slog.Value) error {
 				return nil
 			}
 			return errNoMatch
-		case int:
-			if int64(match) == val.Int64() {
-				return nil
-			}
-			return errNoMatch
 		default:
-			return fmt.Errorf("invalid type for comparing KindInt64: %T", matcher)
+			return fmt.Errorf("matcher of type %T cannot compare KindInt64", matcher)
 		}
 
 	case slog.KindString:
@@ -422,7 +555,7 @@ func matchAttr(matcher any, val slog.Value) error {
 			}
 			return errNoMatch
 		default:
-			return fmt.Errorf("invalid type for comparing KindString: %T", matcher)
+			return fmt.Errorf("matcher of type %T cannot compare KindString", matcher)
 		}
 
 	case slog.KindTime:
@@ -443,7 +576,7 @@ func matchAttr(matcher any, val slog.Value) error {
 			}
 			return errNoMatch
 		default:
-			return fmt.Errorf("invalid type for comparing KindTime: %T", matcher)
+			return fmt.Errorf("matcher of type %T cannot compare KindTime", matcher)
 		}
 
 	case slog.KindUint64:
@@ -463,17 +596,12 @@ func matchAttr(matcher any, val slog.Value) error {
 				return nil
 			}
 			return errNoMatch
-		case int:
-			if uint64(match) == val.Uint64() {
-				return nil
-			}
-			return errNoMatch
 		default:
-			return fmt.Errorf("invalid type for comparing KindUint64: %T", matcher)
+			return fmt.Errorf("matcher of type %T cannot compare KindUint64", matcher)
 		}
 
 	case slog.KindLogValuer:
-		return matchAttr(matcher, val.LogValuer().LogValue())
+		return matchAttrKinds(matcher, val.LogValuer().LogValue(), strictKinds)
 
 	default:
 		// This means slog has apparently added a type this code is
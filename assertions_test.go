@@ -197,14 +197,14 @@ func TestMatchers(t *testing.T) {
 		}
 
 		// idx 0 is a special case; since it's the KindAny
-		// case, there is no value that can be passed to the
-		// first parameter of matchAttr that can't be
-		// reflect.DeepEqual'd against the test.Value to get a
-		// "invalid type" error. Everything else has invalid
-		// type possibilities.
+		// case, there is no value other than a function that
+		// can be passed to the first parameter of matchAttr
+		// that can't be reflect.DeepEqual'd against the
+		// test.Value to get a "cannot compare" error.
+		// Everything else has invalid type possibilities.
 		if idx != 0 {
 			err := matchAttr([]int{}, test.Value)
-			if err == nil || !strings.Contains(err.Error(), "invalid type") {
+			if err == nil || !strings.Contains(err.Error(), "cannot compare") {
 				t.Fatalf("incorrect bad type error: %v", err)
 			}
 		}
@@ -0,0 +1,180 @@
+package slogassert
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"time"
+)
+
+// AttrValue is the set of types that the values of a slog.Value of
+// a given Kind are matched as, by a "func (T) bool" matcher in a
+// LogMessageMatch's Attrs. See [Where].
+type AttrValue interface {
+	bool | time.Duration | float64 | int64 | string | time.Time | uint64
+}
+
+// Where returns its argument unchanged. It exists to check at compile
+// time that a function used as an attribute matcher takes one of the
+// types that slogassert can pass to it, so that, for instance, a
+// "func (int) bool" that would never match a KindInt64 attribute is a
+// compile error rather than a silent failure to match:
+//
+//	slogassert.Msg("charged").
+//		Attr("amount", slogassert.Where(func(amount int64) bool {
+//			return amount > 0
+//		}))
+func Where[T AttrValue](f func(T) bool) func(T) bool {
+	return f
+}
+
+// Msg begins a LogMessageMatch for the given message, matching any
+// level, to be refined with the builder methods on LogMessageMatch:
+//
+//	handler.AssertPrecise(
+//		slogassert.Msg("charged").
+//			AtLevel(slog.LevelInfo).
+//			Attr(slogassert.Key("payment", "amount"), 100),
+//	)
+//
+// The result is an ordinary LogMessageMatch, usable anywhere one is.
+func Msg(message string) LogMessageMatch {
+	return LogMessageMatch{
+		Message: message,
+		Level:   LevelDontCare,
+	}
+}
+
+// AtLevel returns a copy of the LogMessageMatch that matches only the
+// given level.
+func (lmm LogMessageMatch) AtLevel(level slog.Level) LogMessageMatch {
+	lmm.Level = level
+	return lmm
+}
+
+// Attr returns a copy of the LogMessageMatch that also requires the
+// attribute with the given key to match the given matcher. See
+// LogMessageMatch for what matchers may be, and [Key] for building
+// keys.
+//
+// As the matcher is untyped, a matcher function taking a type that
+// slogassert never passes, such as a func(int) bool, compiles, and
+// is only caught at runtime by [LogMessageMatch.Validate]. The
+// compile-time check needs either [AttrFunc] instead of Attr, or the
+// function wrapped in [Where].
+func (lmm LogMessageMatch) Attr(key string, matcher any) LogMessageMatch {
+	attrs := maps.Clone(lmm.Attrs)
+	if attrs == nil {
+		attrs = map[string]any{}
+	}
+	attrs[key] = matcher
+	lmm.Attrs = attrs
+	return lmm
+}
+
+// AttrFunc returns a copy of the LogMessageMatch that also requires
+// the attribute with the given key to satisfy the function. It is
+// lmm.Attr(key, f), except that the type of the function's argument
+// is checked at compile time, as with [Where]:
+//
+//	lmm := slogassert.AttrFunc(slogassert.Msg("charged"), "amount",
+//		func(amount int64) bool { return amount > 0 })
+//
+// It is a function rather than a method because methods can not have
+// type parameters.
+func AttrFunc[T AttrValue](
+	lmm LogMessageMatch,
+	key string,
+	f func(T) bool,
+) LogMessageMatch {
+	return lmm.Attr(key, f)
+}
+
+// InContext returns a copy of the LogMessageMatch that also requires
+// the value extracted by the named context extractor to match the
+// given matcher. See [Handler.ExtractContext].
+func (lmm LogMessageMatch) InContext(name string, matcher any) LogMessageMatch {
+	context := maps.Clone(lmm.Context)
+	if context == nil {
+		context = map[string]any{}
+	}
+	context[name] = matcher
+	lmm.Context = context
+	return lmm
+}
+
+// AllAttrs returns a copy of the LogMessageMatch that requires that
+// the Attrs account for all the attributes in the log message.
+func (lmm LogMessageMatch) AllAttrs() LogMessageMatch {
+	lmm.AllAttrsMatch = true
+	return lmm
+}
+
+// Strict returns a copy of the LogMessageMatch with StrictKinds set,
+// requiring numeric attribute matchers to be exactly the Go type of
+// the attribute's Kind. See LogMessageMatch.
+func (lmm LogMessageMatch) Strict() LogMessageMatch {
+	lmm.StrictKinds = true
+	return lmm
+}
+
+// A CountedMatch is a LogMessageMatch with bounds on how many log
+// messages must match it, as created by [LogMessageMatch.Exactly] and
+// related methods. It is only accepted by [Handler.AssertCount]; the
+// other Assert methods take the LogMessageMatch itself.
+type CountedMatch struct {
+	Match LogMessageMatch
+	Min   int
+	// -1 for unbounded
+	Max int
+}
+
+// Exactly returns a CountedMatch requiring exactly n messages to
+// match, for use with [Handler.AssertCount].
+func (lmm LogMessageMatch) Exactly(n int) CountedMatch {
+	return CountedMatch{lmm, n, n}
+}
+
+// AtLeast returns a CountedMatch requiring at least n messages to
+// match, for use with [Handler.AssertCount].
+func (lmm LogMessageMatch) AtLeast(n int) CountedMatch {
+	return CountedMatch{lmm, n, -1}
+}
+
+// Between returns a CountedMatch requiring between min and max
+// messages, inclusive, to match, for use with [Handler.AssertCount].
+func (lmm LogMessageMatch) Between(min, max int) CountedMatch {
+	return CountedMatch{lmm, min, max}
+}
+
+func (cm CountedMatch) String() string {
+	switch {
+	case cm.Max == -1:
+		return fmt.Sprintf("%s at least %d time(s)", cm.Match, cm.Min)
+	case cm.Min == cm.Max:
+		return fmt.Sprintf("%s exactly %d time(s)", cm.Match, cm.Min)
+	default:
+		return fmt.Sprintf("%s between %d and %d time(s)",
+			cm.Match, cm.Min, cm.Max)
+	}
+}
+
+// AssertCount asserts all the messages matching the CountedMatch's
+// LogMessageMatch, failing the test if the number of them is outside
+// of its bounds. The number of matched messages is returned.
+//
+//	handler.AssertCount(slogassert.Msg("retrying").Exactly(3))
+func (h *Handler) AssertCount(cm CountedMatch) int {
+	h.t.Helper()
+	h.validate(cm.Match)
+	var invalid error
+	matches := h.Assert(checkedMatch(cm.Match, &invalid))
+	if matches < cm.Min || (cm.Max != -1 && matches > cm.Max) {
+		if invalid != nil {
+			h.Fail("Expected logs matching %s, found %d: %s", cm, matches, invalid)
+			return matches
+		}
+		h.Fail("Expected logs matching %s, found %d", cm, matches)
+	}
+	return matches
+}
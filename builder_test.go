@@ -0,0 +1,67 @@
+package slogassert
+
+import (
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	base := Msg("charged")
+	lmm := base.AtLevel(slog.LevelInfo).
+		Attr(Key("payment", "amount"), 100).
+		Attr("ok", Where(func(ok bool) bool { return ok })).
+		AllAttrs()
+
+	if !reflect.DeepEqual(base, LogMessageMatch{
+		Message: "charged",
+		Level:   LevelDontCare,
+	}) {
+		t.Fatal("builder modified its base")
+	}
+	if lmm.Message != "charged" || lmm.Level != slog.LevelInfo ||
+		len(lmm.Attrs) != 2 || lmm.Attrs["payment.amount"] != 100 ||
+		!lmm.AllAttrsMatch {
+		t.Fatalf("incorrect built match: %#v", lmm)
+	}
+
+	// attrs maps aren't shared between derived matches
+	first := base.Attr("a", 1)
+	second := first.Attr("b", 2)
+	if len(first.Attrs) != 1 || len(second.Attrs) != 2 {
+		t.Fatal("builder shares Attrs maps")
+	}
+
+	handler := New(t, slog.LevelInfo, nil)
+	defer handler.AssertEmpty()
+	log := slog.New(handler)
+
+	for i := 0; i < 3; i++ {
+		log.WithGroup("payment").Info("charged", "amount", 100)
+	}
+	log.Info("charged", "ok", true, slog.Group("payment", "amount", 100))
+
+	handler.AssertPrecise(lmm)
+	handler.AssertCount(AttrFunc(Msg("charged"), Key("payment", "amount"),
+		func(amount int64) bool { return amount == 100 }).Exactly(3))
+
+	log.Info("retry")
+	log.Info("retry")
+	if handler.AssertCount(Msg("retry").AtLeast(1)) != 2 {
+		t.Fatal("incorrect AssertCount result")
+	}
+
+	rt := &recordingTester{}
+	handler.t = rt
+	log.Info("retry")
+	handler.AssertCount(Msg("retry").Between(2, 3))
+	handler.AssertCount(Msg("retry").Exactly(1))
+	handler.t = t
+	if len(rt.failures) != 2 ||
+		!strings.Contains(rt.failures[0],
+			`Expected logs matching message "retry" between 2 and 3 time(s), found 1`) ||
+		!strings.Contains(rt.failures[1], "exactly 1 time(s), found 0") {
+		t.Fatalf("incorrect failures: %v", rt.failures)
+	}
+}
@@ -0,0 +1,140 @@
+package slogassert
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// An OverflowPolicy determines what a Handler with a limited capacity
+// does when a record arrives and the capture buffer is full. See
+// [Handler.SetCapacity].
+type OverflowPolicy int
+
+const (
+	// DropOldest drops the oldest unasserted message to make room
+	// for the new one, treating the capture buffer as a ring
+	// buffer.
+	DropOldest OverflowPolicy = iota
+
+	// DropNewest drops the incoming record.
+	DropNewest
+
+	// FailOnOverflow drops the incoming record and fails the test
+	// immediately, from within the Handle call.
+	FailOnOverflow
+)
+
+// SetCapacity limits the number of unasserted messages the Handler
+// will hold to the given capacity, using the given policy when it
+// overflows. A capacity of 0 or less means no limit, which is the
+// default.
+//
+// This is useful for soak tests and fuzzing loops, where logging
+// without limit can consume enormous amounts of memory.
+//
+// The capacity also limits the shadow buffer (see
+// [Handler.SetShadow]) and the messages failed by injected faults
+// (see [Handler.Faulted]), which always keep the newest messages.
+// Messages dropped from those are not counted by [Handler.Dropped].
+//
+// Dropped messages still count as unasserted: if any message that
+// must be asserted (see [EmptyPolicy]) has been dropped,
+// [Handler.AssertEmpty] will fail, even if the buffer is now empty.
+// Use [Handler.Dropped] to see how many messages have been dropped.
+//
+// If the Handler already holds more messages than the new capacity,
+// the excess are dropped immediately, the oldest for DropOldest and
+// the newest otherwise, and counted as dropped.
+func (h *Handler) SetCapacity(capacity int, policy OverflowPolicy) {
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	root.capacity = capacity
+	root.overflow = policy
+	if capacity <= 0 {
+		return
+	}
+
+	if excess := len(root.logMessages) - capacity; excess > 0 {
+		var dropped []LogMessage
+		if policy == DropOldest {
+			dropped = root.logMessages[:excess]
+			root.logMessages = root.logMessages[excess:]
+		} else {
+			dropped = root.logMessages[capacity:]
+			root.logMessages = root.logMessages[:capacity]
+		}
+		for _, lm := range dropped {
+			root.dropped[lm.Level]++
+		}
+	}
+	if len(root.shadow) > capacity {
+		root.shadow = root.shadow[len(root.shadow)-capacity:]
+	}
+	if len(root.faulted) > capacity {
+		root.faulted = root.faulted[len(root.faulted)-capacity:]
+	}
+}
+
+// Dropped returns the number of messages that have been dropped due
+// to the capacity limit set with [Handler.SetCapacity]. See also
+// [Handler.UnassertedAndDropped].
+func (h *Handler) Dropped() int {
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	return root.droppedMatching(func(slog.Level) bool { return true })
+}
+
+// capture adds the log message to the unasserted messages, subject
+// to the capacity limit, returning a failure to report if any.
+//
+// should be run only under handler lock
+func (h *Handler) capture(lm LogMessage) string {
+	if h.capacity <= 0 || len(h.logMessages) < h.capacity {
+		h.logMessages = append(h.logMessages, lm)
+		return ""
+	}
+
+	switch h.overflow {
+	case DropOldest:
+		h.dropped[h.logMessages[0].Level]++
+		h.logMessages = append(h.logMessages[1:], lm)
+		return ""
+	case DropNewest:
+		h.dropped[lm.Level]++
+		return ""
+	default:
+		h.dropped[lm.Level]++
+		return fmt.Sprintf(
+			"log message %q dropped: capture buffer is full at %d message(s)",
+			lm.Message, h.capacity,
+		)
+	}
+}
+
+// should be run only under handler lock
+func (h *Handler) droppedMatching(include func(slog.Level) bool) int {
+	total := 0
+	for level, count := range h.dropped {
+		if include(level) {
+			total += count
+		}
+	}
+	return total
+}
+
+// appendBounded appends the log message to one of the Handler's
+// other buffers, dropping the oldest message if that would exceed
+// the capacity limit.
+//
+// should be run only under handler lock
+func (h *Handler) appendBounded(msgs []LogMessage, lm LogMessage) []LogMessage {
+	msgs = append(msgs, lm)
+	if h.capacity > 0 && len(msgs) > h.capacity {
+		msgs = msgs[len(msgs)-h.capacity:]
+	}
+	return msgs
+}
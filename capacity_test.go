@@ -0,0 +1,137 @@
+package slogassert
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestCapacityDropOldest(t *testing.T) {
+	rt := &recordingTester{}
+	handler := New(rt, slog.LevelDebug, nil)
+	handler.SetReporter(&capturingReporter{})
+	handler.SetCapacity(2, DropOldest)
+	log := slog.New(handler)
+
+	for i := 0; i < 5; i++ {
+		log.Info(fmt.Sprint(i))
+	}
+
+	msgs := handler.Unasserted()
+	if len(msgs) != 2 || msgs[0].Message != "3" || msgs[1].Message != "4" {
+		t.Fatalf("incorrect ring buffer contents: %#v", msgs)
+	}
+	if handler.Dropped() != 3 {
+		t.Fatalf("incorrect dropped count: %d", handler.Dropped())
+	}
+	msgs, dropped := handler.UnassertedAndDropped()
+	if len(msgs) != 2 || dropped != 3 {
+		t.Fatalf("incorrect UnassertedAndDropped: %#v %d", msgs, dropped)
+	}
+
+	handler.AssertSomeMessage("3")
+	handler.AssertSomeMessage("4")
+	handler.AssertEmpty()
+	if len(rt.failures) != 1 ||
+		!strings.Contains(rt.failures[0], "0 unasserted log message(s), and 3 more dropped") {
+		t.Fatalf("dropped messages did not fail AssertEmpty: %v", rt.failures)
+	}
+
+	// unless the policy says those levels don't matter
+	handler.SetEmptyPolicy(EmptyPolicy{Assert: slog.LevelWarn})
+	handler.AssertEmpty()
+	if len(rt.failures) != 1 {
+		t.Fatal("dropped messages ignored the empty policy")
+	}
+
+	handler.Reset()
+	if handler.Dropped() != 0 {
+		t.Fatal("Reset did not clear dropped count")
+	}
+}
+
+func TestCapacityDropNewest(t *testing.T) {
+	handler := New(t, slog.LevelDebug, nil)
+	handler.SetCapacity(2, DropNewest)
+	log := slog.New(handler)
+
+	for i := 0; i < 5; i++ {
+		log.Info(fmt.Sprint(i))
+	}
+
+	msgs := handler.Unasserted()
+	if len(msgs) != 2 || msgs[0].Message != "0" || msgs[1].Message != "1" ||
+		handler.Dropped() != 3 {
+		t.Fatalf("incorrect buffer contents: %#v", msgs)
+	}
+	handler.Reset()
+}
+
+func TestCapacityFail(t *testing.T) {
+	et := &erroringTester{}
+	handler := NewDefault(t, WithCapacity(1, FailOnOverflow))
+	handler.t = et
+	slog.Info("first")
+	slog.Info("second")
+
+	if len(et.errors) != 1 ||
+		!strings.Contains(et.errors[0],
+			`log message "second" dropped: capture buffer is full at 1 message(s)`) {
+		t.Fatalf("incorrect overflow failure: %v", et.errors)
+	}
+	handler.t = t
+	handler.Reset()
+}
+
+func TestCapacityOtherBuffers(t *testing.T) {
+	handler := New(t, slog.LevelInfo, nil)
+	handler.SetShadow(slog.LevelDebug)
+	handler.SetCapacity(2, DropNewest)
+	handler.InjectFault(Fault{
+		Match: &LogMessageMatch{Message: "fail", Level: LevelDontCare},
+		Err:   ErrInjectedFault,
+	})
+	log := slog.New(handler)
+
+	for i := 0; i < 5; i++ {
+		log.Debug(fmt.Sprint(i))
+		log.Info("fail")
+	}
+
+	shadow := handler.Shadow()
+	if len(shadow) != 2 || shadow[0].Message != "3" || shadow[1].Message != "4" {
+		t.Fatalf("incorrect shadow buffer: %#v", shadow)
+	}
+	if len(handler.Faulted()) != 2 || handler.Dropped() != 0 {
+		t.Fatalf("incorrect faulted buffer: %#v", handler.Faulted())
+	}
+}
+
+func TestCapacityLowered(t *testing.T) {
+	handler := New(t, slog.LevelDebug, nil)
+	log := slog.New(handler)
+	for i := 0; i < 5; i++ {
+		log.Info(fmt.Sprint(i))
+	}
+
+	handler.SetCapacity(2, DropOldest)
+	log.Info("5")
+	msgs, dropped := handler.UnassertedAndDropped()
+	if len(msgs) != 2 || msgs[0].Message != "4" || msgs[1].Message != "5" ||
+		dropped != 4 {
+		t.Fatalf("incorrect buffer after lowering capacity: %#v %d", msgs, dropped)
+	}
+	handler.Reset()
+
+	handler.SetCapacity(0, DropOldest)
+	for i := 0; i < 5; i++ {
+		log.Info(fmt.Sprint(i))
+	}
+	handler.SetCapacity(3, DropNewest)
+	msgs, dropped = handler.UnassertedAndDropped()
+	if len(msgs) != 3 || msgs[2].Message != "2" || dropped != 2 {
+		t.Fatalf("incorrect buffer after lowering capacity: %#v %d", msgs, dropped)
+	}
+	handler.Reset()
+}
@@ -0,0 +1,61 @@
+/*
+Command slogassert-gen reads JSON log output, as produced by
+slog.JSONHandler, and writes out Go source code containing
+slogassert AssertPrecise calls that would assert those log messages.
+
+Usage:
+
+	slogassert-gen [file ...]
+
+If no files are given, the log is read from standard input. The
+generated code is written to standard output; it is intended to be
+pasted into a test and then edited down to what the test really cares
+about.
+
+To generate assertions directly from a test's captured logs, see
+Handler.GenerateAssertions instead.
+*/
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/thejerf/slogassert"
+)
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "slogassert-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) == 0 {
+		return generate(stdin, stdout)
+	}
+
+	for _, filename := range args {
+		f, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		err = generate(f, stdout)
+		_ = f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", filename, err)
+		}
+	}
+	return nil
+}
+
+func generate(r io.Reader, w io.Writer) error {
+	msgs, err := slogassert.ReadJSONLog(r)
+	if err != nil {
+		return err
+	}
+	return slogassert.WriteAssertions(w, msgs)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testLog = `{"time":"2024-07-25T01:02:03Z","level":"INFO","msg":"hello","user":{"id":42}}
+`
+
+func TestRun(t *testing.T) {
+	out := &strings.Builder{}
+	err := run(nil, strings.NewReader(testLog), out)
+	if err != nil {
+		t.Fatalf("could not run on stdin: %v", err)
+	}
+	if !strings.Contains(out.String(), `"user.id": 42,`) {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+
+	filename := filepath.Join(t.TempDir(), "log.json")
+	err = os.WriteFile(filename, []byte(testLog+"not json\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = run([]string{filename}, nil, &strings.Builder{})
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("incorrect error for bad file: %v", err)
+	}
+
+	err = run([]string{filepath.Join(t.TempDir(), "missing")}, nil, out)
+	if err == nil {
+		t.Fatal("no error for missing file")
+	}
+}
@@ -0,0 +1,131 @@
+package slogassert
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+)
+
+// A ContextExtractor pulls a value out of the context.Context passed
+// to Handle, such as a request ID, tenant, or trace ID. It returns
+// false if the context does not carry the value.
+//
+// Extractors are called with the handler lock held, so they must not
+// log to the Handler.
+type ContextExtractor func(ctx context.Context) (any, bool)
+
+// ContextValue returns a ContextExtractor for the value stored in the
+// context under the given key with context.WithValue.
+func ContextValue(key any) ContextExtractor {
+	return func(ctx context.Context) (any, bool) {
+		val := ctx.Value(key)
+		return val, val != nil
+	}
+}
+
+type contextExtractor struct {
+	name    string
+	extract ContextExtractor
+}
+
+// ExtractContext registers a ContextExtractor under the given name.
+// Every record subsequently handled will have the extracted value, if
+// any, stored in its LogMessage's Context under that name, where it
+// can be matched with LogMessageMatch's Context and checked with
+// [Handler.AssertCorrelated].
+//
+// Registering a second extractor with the same name replaces the
+// first.
+func (h *Handler) ExtractContext(name string, extractor ContextExtractor) {
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	for idx, ce := range root.extractors {
+		if ce.name == name {
+			root.extractors[idx].extract = extractor
+			return
+		}
+	}
+	root.extractors = append(root.extractors, contextExtractor{name, extractor})
+}
+
+// should be run only under handler lock
+func (h *Handler) extractContext(ctx context.Context) map[string]any {
+	if ctx == nil || len(h.extractors) == 0 {
+		return nil
+	}
+
+	values := map[string]any{}
+	for _, ce := range h.extractors {
+		val, ok := ce.extract(ctx)
+		if ok {
+			values[ce.name] = val
+		}
+	}
+	return values
+}
+
+// matchContext is matchAttrs for a LogMessageMatch's Context.
+func matchContext(
+	matchers map[string]any,
+	values map[string]any,
+	strictKinds bool,
+	explain bool,
+) error {
+	return eachKey(matchers, explain, func(name string) error {
+		val, haveVal := values[name]
+		if !haveVal {
+			return explainf(explain, "context `%s` is missing", name)
+		}
+		err := matchAttrKinds(matchers[name], slog.AnyValue(val), strictKinds)
+		switch {
+		case err == nil:
+			return nil
+		case !explain && errors.Is(err, errNoMatch):
+			return errNoMatch
+		case errors.Is(err, errNoMatch):
+			return &mismatch{"context `" + name + "`", err.Error()}
+		default:
+			return &InvalidMatcherError{Key: name, Context: true, Err: err}
+		}
+	})
+}
+
+// AssertCorrelated asserts that every unasserted log message carries
+// the same value for the named context extractor, and returns that
+// value. It fails the test if any message lacks the value, or if
+// there are no messages at all.
+//
+// This is intended for verifying that a logger is correctly carrying
+// a context through some operation, such as an HTTP request, so that
+// all of its records can be correlated by a request or trace ID. It
+// does not assert the messages.
+func (h *Handler) AssertCorrelated(name string) any {
+	h.t.Helper()
+	msgs := h.Unasserted()
+	if len(msgs) == 0 {
+		h.Fail("no log messages to correlate by context %q", name)
+		return nil
+	}
+
+	var first any
+	for idx, lm := range msgs {
+		val, haveVal := lm.Context[name]
+		if !haveVal {
+			h.Fail("log message %q has no context %q", lm.Message, name)
+			return nil
+		}
+		if idx == 0 {
+			first = val
+			continue
+		}
+		if !reflect.DeepEqual(val, first) {
+			h.Fail("log message %q has context %q of %#v, but %q has %#v",
+				lm.Message, name, val, msgs[0].Message, first)
+			return nil
+		}
+	}
+	return first
+}
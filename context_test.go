@@ -0,0 +1,115 @@
+package slogassert
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type ctxKey string
+
+const (
+	requestIDKey = ctxKey("request_id")
+	tenantKey    = ctxKey("tenant")
+)
+
+func TestExtractContext(t *testing.T) {
+	handler := New(t, slog.LevelInfo, nil)
+	defer handler.AssertEmpty()
+	handler.ExtractContext("request_id", ContextValue(requestIDKey))
+	handler.ExtractContext("tenant", func(ctx context.Context) (any, bool) {
+		tenant, ok := ctx.Value(tenantKey).(string)
+		return strings.ToUpper(tenant), ok
+	})
+	log := slog.New(handler).With("component", "billing")
+
+	ctx := context.WithValue(context.Background(), requestIDKey, "req-1")
+	ctx = context.WithValue(ctx, tenantKey, "acme")
+	log.InfoContext(ctx, "charged")
+	log.Info("no context")
+
+	msgs := handler.Unasserted()
+	if msgs[0].Context["request_id"] != "req-1" ||
+		msgs[0].Context["tenant"] != "ACME" {
+		t.Fatalf("context not extracted: %#v", msgs[0].Context)
+	}
+	if len(msgs[1].Context) != 0 {
+		t.Fatalf("context extracted from background: %#v", msgs[1].Context)
+	}
+
+	matched := Msg("charged").InContext("request_id", "req-1")
+	if !matched.Matches(msgs[0]) || matched.Matches(msgs[1]) {
+		t.Fatal("incorrect context matching")
+	}
+	err := Msg("no context").InContext("request_id", "req-1").MatchError(msgs[1])
+	if err == nil || err.Error() != "context `request_id` is missing" {
+		t.Fatalf("incorrect context mismatch: %v", err)
+	}
+	err = matched.InContext("tenant", "acme").MatchError(msgs[0])
+	if err == nil || err.Error() != "context `tenant`: does not match" {
+		t.Fatalf("incorrect context mismatch: %v", err)
+	}
+	err = matched.InContext("tenant", func(int) bool { return true }).Validate()
+	if err == nil || !strings.HasPrefix(err.Error(), "context `tenant`: matcher of type") {
+		t.Fatalf("context matchers not validated: %v", err)
+	}
+	if matched.String() != `message "charged" context {request_id: "req-1"}` {
+		t.Fatalf("incorrect description: %s", matched)
+	}
+
+	handler.AssertPrecise(matched.InContext("tenant", "ACME"))
+	handler.AssertMessage("no context")
+
+	// replacing an extractor
+	handler.ExtractContext("tenant", ContextValue(tenantKey))
+	log.InfoContext(ctx, "charged")
+	handler.AssertPrecise(Msg("charged").InContext("tenant", "acme"))
+}
+
+func TestAssertCorrelated(t *testing.T) {
+	handler := New(t, slog.LevelInfo, nil)
+	defer handler.AssertEmpty()
+	handler.ExtractContext("request_id", ContextValue(requestIDKey))
+	log := slog.New(handler)
+
+	ctx := context.WithValue(context.Background(), requestIDKey, "req-1")
+	log.InfoContext(ctx, "start")
+	log.InfoContext(ctx, "end")
+	if handler.AssertCorrelated("request_id") != "req-1" {
+		t.Fatal("incorrect correlation ID returned")
+	}
+
+	rt := &recordingTester{}
+	handler.t = rt
+	log.InfoContext(context.WithValue(ctx, requestIDKey, "req-2"), "leaked")
+	handler.AssertCorrelated("request_id")
+	handler.AssertMessage("leaked")
+	log.Info("lost")
+	handler.AssertCorrelated("request_id")
+	handler.t = t
+	if len(rt.failures) != 2 ||
+		rt.failures[0] != `log message "leaked" has context "request_id" of "req-2", but "start" has "req-1"` ||
+		rt.failures[1] != `log message "lost" has no context "request_id"` {
+		t.Fatalf("incorrect failures: %#v", rt.failures)
+	}
+	handler.Reset()
+
+	handler.t = rt
+	handler.AssertCorrelated("request_id")
+	handler.t = t
+	if len(rt.failures) != 3 {
+		t.Fatal("correlating no messages did not fail")
+	}
+}
+
+func TestWithContextExtractor(t *testing.T) {
+	handler := NewDefault(t,
+		WithLeveler(slog.LevelInfo),
+		WithContextExtractor("request_id", ContextValue(requestIDKey)),
+	)
+	defer handler.AssertEmpty()
+
+	slog.InfoContext(context.WithValue(context.Background(), requestIDKey, 7), "hi")
+	handler.AssertPrecise(Msg("hi").InContext("request_id", 7))
+}
@@ -12,7 +12,18 @@ import (
 type config struct {
 	level       slog.Leveler
 	assertEmpty bool
+	emptyPolicy EmptyPolicy
 	wrapped     slog.Handler
+	echo        bool
+	shadow      slog.Leveler
+	reporter    Reporter
+	ignores     []LogMessageMatch
+	strict      slog.Leveler
+	capacity    int
+	overflow    OverflowPolicy
+	extractors  []contextExtractor
+	recordTree  bool
+	stats       bool
 }
 
 // An Option allows for configuration of the default handler created
@@ -31,9 +42,15 @@ func WithLeveler(level slog.Leveler) Option {
 // WithAssertEmpty is a functional option for [NewDefault] that configures
 // the handler to validated that all messages have been captured and
 // asserted.
-func WithAssertEmpty() Option {
+//
+// If an EmptyPolicy is given, it is set on the handler; see
+// [EmptyPolicy]. If more than one is given, the last is used.
+func WithAssertEmpty(policy ...EmptyPolicy) Option {
 	return func(c *config) {
 		c.assertEmpty = true
+		if len(policy) > 0 {
+			c.emptyPolicy = policy[len(policy)-1]
+		}
 	}
 }
 
@@ -47,6 +64,87 @@ func WithWrapped(wrapped slog.Handler) Option {
 	}
 }
 
+// WithEcho is a functional option for [NewDefault] that mirrors every
+// handled record to the test's log. See [Handler.SetEcho].
+func WithEcho() Option {
+	return func(c *config) {
+		c.echo = true
+	}
+}
+
+// WithShadow is a functional option for [NewDefault] that keeps
+// records below the handler's level, down to the given shadow level,
+// and prints them if the test fails. See [Handler.SetShadow].
+func WithShadow(shadow slog.Leveler) Option {
+	return func(c *config) {
+		c.shadow = shadow
+	}
+}
+
+// WithReporter is a functional option for [NewDefault] that sets the
+// Reporter used to print out log messages when a test fails. See
+// [Handler.SetReporter].
+func WithReporter(reporter Reporter) Option {
+	return func(c *config) {
+		c.reporter = reporter
+	}
+}
+
+// WithIgnore is a functional option for [NewDefault] that installs
+// the given ignore rules on the handler. See [Handler.Ignore].
+func WithIgnore(lmms ...LogMessageMatch) Option {
+	return func(c *config) {
+		c.ignores = append(c.ignores, lmms...)
+	}
+}
+
+// WithStrict is a functional option for [NewDefault] that puts the
+// handler into strict mode, failing the test immediately on any
+// unexpected record at or above the given level. See
+// [Handler.SetStrict].
+func WithStrict(strict slog.Leveler) Option {
+	return func(c *config) {
+		c.strict = strict
+	}
+}
+
+// WithCapacity is a functional option for [NewDefault] that limits
+// the number of unasserted messages the handler will hold. See
+// [Handler.SetCapacity].
+func WithCapacity(capacity int, policy OverflowPolicy) Option {
+	return func(c *config) {
+		c.capacity = capacity
+		c.overflow = policy
+	}
+}
+
+// WithContextExtractor is a functional option for [NewDefault] that
+// registers a ContextExtractor on the handler under the given name.
+// See [Handler.ExtractContext].
+func WithContextExtractor(name string, extractor ContextExtractor) Option {
+	return func(c *config) {
+		c.extractors = append(c.extractors, contextExtractor{name, extractor})
+	}
+}
+
+// WithTree is a functional option for [NewDefault] that records the
+// tree of Handlers derived from the default handler. See
+// [Handler.SetRecordTree].
+func WithTree() Option {
+	return func(c *config) {
+		c.recordTree = true
+	}
+}
+
+// WithStats is a functional option for [NewDefault] that collects
+// statistics on the records the default handler handles. See
+// [Handler.SetStats].
+func WithStats() Option {
+	return func(c *config) {
+		c.stats = true
+	}
+}
+
 // NewDefault is a helper function for tests that creates a slogassert [Handler]
 // and sets it as the default slog handler
 // Once the test is complete it will attempt to restore the previous handler.
@@ -55,6 +153,15 @@ func WithWrapped(wrapped slog.Handler) Option {
 //   - [WithLeveler] to set the log level
 //   - [WithAssertEmpty] to assert that the handler is empty at the end of the test
 //   - [WithWrapped] to wrap the handler with another handler
+//   - [WithEcho] to mirror captured records to the test log
+//   - [WithShadow] to print records below the log level on failure
+//   - [WithReporter] to change where failure output is printed
+//   - [WithIgnore] to ignore known-noisy messages
+//   - [WithStrict] to fail immediately on unexpected records
+//   - [WithCapacity] to limit how many messages are held
+//   - [WithContextExtractor] to record values from the context
+//   - [WithTree] to record the tree of derived handlers
+//   - [WithStats] to collect statistics on the records
 //
 // Example:
 //
@@ -81,6 +188,22 @@ func NewDefault(t testing.TB, opts ...Option) *Handler {
 	}
 
 	handler := New(t, c.level, c.wrapped)
+	handler.SetEcho(c.echo)
+	handler.SetRecordTree(c.recordTree)
+	handler.SetStats(c.stats)
+	handler.SetReporter(c.reporter)
+	for _, ce := range c.extractors {
+		handler.ExtractContext(ce.name, ce.extract)
+	}
+	for _, lmm := range c.ignores {
+		handler.Ignore(lmm)
+	}
+	handler.SetStrict(c.strict)
+	handler.SetEmptyPolicy(c.emptyPolicy)
+	handler.SetCapacity(c.capacity, c.overflow)
+	if c.shadow != nil {
+		handler.SetShadow(c.shadow)
+	}
 
 	// take a copy of the original logger and flags so that we can restore
 	// once the test is complete
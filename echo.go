@@ -0,0 +1,121 @@
+package slogassert
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// logfer is implemented by testing.TB, and anything else that can log
+// test output.
+type logfer interface {
+	Logf(string, ...any)
+}
+
+// cleanuper is implemented by testing.TB, and allows the shadow
+// buffer to be printed out when a test fails.
+type cleanuper interface {
+	Cleanup(func())
+	Failed() bool
+}
+
+// SetEcho sets whether the Handler mirrors every record it handles
+// to the test's log, as it is handled, in the style of zaptest. This
+// includes records that are ignored, go to the shadow buffer, fail
+// the test in strict mode, or are failed by an injected fault, as
+// those are often the ones needed to debug a failing test. This
+// requires the Tester to have a Logf method, as *testing.T does; if
+// it does not, this has no effect.
+//
+// Note that as with all calls to Logf, records logged after the test
+// has completed will cause a panic.
+func (h *Handler) SetEcho(echo bool) {
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	root.echo = echo
+}
+
+// SetShadow configures the Handler to keep records that are below
+// its level, but at or above the given shadow level, in a separate
+// "shadow" buffer. These records are not asserted on and are not
+// passed to any wrapped handler; instead, if the test fails, they
+// are printed out to provide full debugging context for the failure.
+//
+// For instance, a Handler created at slog.LevelWarn with a shadow
+// level of slog.LevelDebug will require all Warn and Error messages
+// to be asserted, but will also print out all the Debug and Info
+// messages if the test fails.
+//
+// If the Tester has Cleanup and Failed methods, as *testing.T does,
+// the shadow buffer will be printed during cleanup if the test
+// failed for any reason. Otherwise, it is printed only when this
+// Handler fails the test itself.
+//
+// Passing nil turns the shadow buffer off and discards it.
+func (h *Handler) SetShadow(leveler slog.Leveler) {
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	root.shadowLeveler = leveler
+	if leveler == nil {
+		root.shadow = nil
+		return
+	}
+
+	c, isCleanuper := root.t.(cleanuper)
+	if isCleanuper && !root.shadowCleanup {
+		root.shadowCleanup = true
+		c.Cleanup(func() {
+			if c.Failed() {
+				root.printShadow()
+			}
+		})
+	}
+}
+
+// Shadow returns the records currently in the shadow buffer. The
+// returned result is a deep copy. See [Handler.SetShadow].
+func (h *Handler) Shadow() []LogMessage {
+	msgs := []LogMessage{}
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	for _, msg := range root.shadow {
+		msgs = append(msgs, msg.clone())
+	}
+	return msgs
+}
+
+// should be run only under handler lock
+func (h *Handler) shadowed(level slog.Level) bool {
+	return h.shadowLeveler != nil && level >= h.shadowLeveler.Level()
+}
+
+// should NOT be run under the handler lock
+func (h *Handler) printShadow() {
+	h.t.Helper()
+	root := h.root()
+	root.m.Lock()
+	shadow := root.shadow
+	root.shadow = nil
+	root.m.Unlock()
+
+	h.report(
+		fmt.Sprintf("%d record(s) below the handler's level:", len(shadow)),
+		shadow,
+	)
+}
+
+// echoLine renders the log message as a single line, for echoing.
+func (lm *LogMessage) echoLine() string {
+	line := &strings.Builder{}
+	fmt.Fprintf(line, "%s %q", lm.Level, lm.Message)
+	for _, key := range sortedKeys(lm.Attrs) {
+		fmt.Fprintf(line, " %s=%v", key, lm.Attrs[key].Any())
+	}
+	return line.String()
+}
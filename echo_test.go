@@ -0,0 +1,125 @@
+package slogassert
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// loggingTester is a recordingTester that also records Logf calls and
+// supports Cleanup.
+type loggingTester struct {
+	recordingTester
+	logs     []string
+	cleanups []func()
+	failed   bool
+}
+
+func (lt *loggingTester) Logf(msg string, args ...any) {
+	lt.logs = append(lt.logs, fmt.Sprintf(msg, args...))
+}
+
+func (lt *loggingTester) Cleanup(f func()) {
+	lt.cleanups = append(lt.cleanups, f)
+}
+
+func (lt *loggingTester) Failed() bool {
+	return lt.failed || len(lt.failures) > 0
+}
+
+func (lt *loggingTester) runCleanups() {
+	for i := len(lt.cleanups) - 1; i >= 0; i-- {
+		lt.cleanups[i]()
+	}
+}
+
+func TestEcho(t *testing.T) {
+	lt := &loggingTester{}
+	handler := New(lt, slog.LevelInfo, nil)
+	handler.SetEcho(true)
+	handler.SetShadow(slog.LevelDebug)
+	handler.Ignore(Msg("noisy"))
+	log := slog.New(handler)
+
+	log.WithGroup("g").Info("hello", "a", 1, "b", "x")
+	log.Debug("shadowed")
+	log.Info("noisy")
+	handler.SetEcho(false)
+	log.Info("not echoed")
+	handler.Reset()
+
+	// records that are not captured are echoed too
+	if len(lt.logs) != 3 || lt.logs[0] != `INFO "hello" g.a=1 g.b=x` ||
+		lt.logs[1] != `DEBUG "shadowed"` || lt.logs[2] != `INFO "noisy"` {
+		t.Fatalf("incorrect echo: %#v", lt.logs)
+	}
+
+	// and this is a no-op for a Tester without Logf
+	rt := &recordingTester{}
+	handler = New(rt, slog.LevelInfo, nil)
+	handler.SetEcho(true)
+	slog.New(handler).Info("hello")
+	handler.AssertMessage("hello")
+}
+
+func TestShadow(t *testing.T) {
+	lt := &loggingTester{}
+	wrapped := &strings.Builder{}
+	handler := New(lt, slog.LevelWarn, slog.NewTextHandler(wrapped, nil))
+	handler.SetShadow(slog.LevelInfo)
+	log := slog.New(handler)
+
+	log.Debug("dropped")
+	log.Info("shadowed")
+	log.Warn(testWarning)
+
+	shadow := handler.Shadow()
+	if len(shadow) != 1 || shadow[0].Message != "shadowed" {
+		t.Fatalf("incorrect shadow: %#v", shadow)
+	}
+	if strings.Contains(wrapped.String(), "shadowed") {
+		t.Fatal("shadowed record passed to wrapped handler")
+	}
+	handler.AssertMessage(testWarning)
+	handler.AssertEmpty()
+
+	// passing test: shadow not printed
+	lt.runCleanups()
+	if len(lt.logs) != 0 {
+		t.Fatalf("shadow printed for passing test: %v", lt.logs)
+	}
+
+	// failing test: shadow printed
+	lt.failed = true
+	lt.runCleanups()
+	if len(lt.logs) != 1 ||
+		!strings.Contains(lt.logs[0], "1 record(s) below") ||
+		!strings.Contains(lt.logs[0], "message:    shadowed") {
+		t.Fatalf("shadow not printed for failing test: %v", lt.logs)
+	}
+
+	handler.SetShadow(nil)
+	if len(handler.Shadow()) != 0 {
+		t.Fatal("shadow not cleared")
+	}
+	log.Info("no longer shadowed")
+	if len(handler.Shadow()) != 0 || len(handler.Unasserted()) != 0 {
+		t.Fatal("shadow not turned off")
+	}
+}
+
+func TestDefaultEchoShadow(t *testing.T) {
+	handler := NewDefault(t,
+		WithLeveler(slog.LevelWarn),
+		WithEcho(),
+		WithShadow(slog.LevelDebug),
+	)
+	slog.Debug("shadowed")
+	slog.Warn(testWarning)
+	handler.AssertMessage(testWarning)
+
+	if len(handler.Shadow()) != 1 {
+		t.Fatal("shadow not set up by NewDefault")
+	}
+}
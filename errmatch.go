@@ -0,0 +1,132 @@
+package slogassert
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// These matchers are intended for use as the values in a
+// LogMessageMatch's Attrs, for attributes that hold errors, as
+// created by slog.Any("err", err). They do not match attributes that
+// do not contain errors.
+
+// attrError extracts the error from a slog.Value, if it has one.
+func attrError(val slog.Value) (error, bool) {
+	if val.Kind() != slog.KindAny {
+		return nil, false
+	}
+	err, isErr := val.Any().(error)
+	return err, isErr && err != nil
+}
+
+// ErrorIs returns a matcher that matches an error attribute if
+// errors.Is(err, target) is true.
+func ErrorIs(target error) func(slog.Value) bool {
+	return func(val slog.Value) bool {
+		err, isErr := attrError(val)
+		return isErr && errors.Is(err, target)
+	}
+}
+
+// ErrorAs returns a matcher that matches an error attribute if
+// errors.As can find an error of type T in it. If checks are given,
+// the found error must also pass all of them.
+//
+// As with errors.As, T must be an interface type or implement error,
+// or this will panic when used.
+func ErrorAs[T any](checks ...func(T) bool) func(slog.Value) bool {
+	return func(val slog.Value) bool {
+		err, isErr := attrError(val)
+		if !isErr {
+			return false
+		}
+		var target T
+		if !errors.As(err, &target) {
+			return false
+		}
+		for _, check := range checks {
+			if !check(target) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// ErrorMessage returns a matcher that matches an error attribute by
+// its Error() message, either by equality to a string, or by a
+// regular expression matching it.
+func ErrorMessage[M string | *regexp.Regexp](message M) func(slog.Value) bool {
+	return func(val slog.Value) bool {
+		err, isErr := attrError(val)
+		if !isErr {
+			return false
+		}
+		switch m := any(message).(type) {
+		case string:
+			return err.Error() == m
+		case *regexp.Regexp:
+			return m.MatchString(err.Error())
+		default:
+			// can't happen due to the type constraint
+			return false
+		}
+	}
+}
+
+// ErrorChainContains returns a matcher that matches an error
+// attribute if any error in its chain, including all branches of
+// errors created with errors.Join, has an Error() message containing
+// the given string.
+func ErrorChainContains(substr string) func(slog.Value) bool {
+	return func(val slog.Value) bool {
+		err, isErr := attrError(val)
+		if !isErr {
+			return false
+		}
+		found := false
+		walkErrors(err, 0, func(err error, _ int) {
+			if strings.Contains(err.Error(), substr) {
+				found = true
+			}
+		})
+		return found
+	}
+}
+
+// walkErrors calls f on the error and everything it wraps, with the
+// depth in the tree.
+func walkErrors(err error, depth int, f func(error, int)) {
+	if err == nil {
+		return
+	}
+	f(err, depth)
+
+	switch wrapper := err.(type) {
+	case interface{ Unwrap() error }:
+		walkErrors(wrapper.Unwrap(), depth+1, f)
+	case interface{ Unwrap() []error }:
+		for _, wrapped := range wrapper.Unwrap() {
+			walkErrors(wrapped, depth+1, f)
+		}
+	}
+}
+
+// writeErrorChain writes out the full tree of errors wrapped by err,
+// one per line, for LogMessage.Print.
+func writeErrorChain(msg *strings.Builder, err error) {
+	switch err.(type) {
+	case interface{ Unwrap() error }, interface{ Unwrap() []error }:
+	default:
+		// nothing to show beyond the error message itself
+		return
+	}
+
+	walkErrors(err, 0, func(err error, depth int) {
+		msg.WriteString(strings.Repeat("  ", depth+2))
+		msg.WriteString(fmt.Sprintf("%T: %v\n", err, err))
+	})
+}
@@ -0,0 +1,91 @@
+package slogassert
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestErrorMatchers(t *testing.T) {
+	base := &fs.PathError{Op: "open", Path: "/x", Err: fs.ErrNotExist}
+	wrapped := fmt.Errorf("loading config: %w", base)
+	joined := errors.Join(errors.New("first"), wrapped)
+	val := slog.AnyValue(joined)
+
+	for idx, test := range []struct {
+		matcher func(slog.Value) bool
+		matches bool
+	}{
+		{ErrorIs(fs.ErrNotExist), true},
+		{ErrorIs(fs.ErrExist), false},
+		{ErrorAs[*fs.PathError](), true},
+		{ErrorAs[*fs.PathError](func(pe *fs.PathError) bool {
+			return pe.Path == "/x"
+		}), true},
+		{ErrorAs[*fs.PathError](func(pe *fs.PathError) bool {
+			return pe.Path == "/y"
+		}), false},
+		{ErrorAs[interface{ Timeout() bool }](), true},
+		{ErrorAs[interface{ Temporary() bool }](), false},
+		{ErrorMessage(joined.Error()), true},
+		{ErrorMessage("first"), false},
+		{ErrorMessage(regexp.MustCompile(`^first\nloading`)), true},
+		{ErrorMessage(regexp.MustCompile(`^loading`)), false},
+		{ErrorChainContains("loading config"), true},
+		{ErrorChainContains("does not exist"), true},
+		{ErrorChainContains("nope"), false},
+	} {
+		if test.matcher(val) != test.matches {
+			t.Fatalf("test %d: incorrect match result", idx)
+		}
+		// and none of them match a non-error
+		if test.matcher(slog.StringValue("first")) ||
+			test.matcher(slog.AnyValue(nil)) {
+			t.Fatalf("test %d: matched a non-error", idx)
+		}
+	}
+
+	handler := New(t, slog.LevelWarn, nil)
+	defer handler.AssertEmpty()
+	slog.New(handler).Warn(testWarning, "err", wrapped)
+	handler.AssertPrecise(LogMessageMatch{
+		Message: testWarning,
+		Level:   slog.LevelWarn,
+		Attrs: map[string]any{
+			"err": ErrorIs(fs.ErrNotExist),
+		},
+	})
+}
+
+func TestPrintErrorChain(t *testing.T) {
+	joined := errors.Join(
+		errors.New("first"),
+		fmt.Errorf("wrapped: %w", errors.New("inner")),
+	)
+	lm := LogMessage{
+		Message: testWarning,
+		Attrs: map[string]slog.Value{
+			"err":   slog.AnyValue(joined),
+			"plain": slog.AnyValue(errors.New("plain")),
+		},
+	}
+	out := &strings.Builder{}
+	lm.Print(out)
+
+	expected := `  err -> (Any) first
+wrapped: inner
+    *errors.joinError: first
+wrapped: inner
+      *errors.errorString: first
+      *fmt.wrapError: wrapped: inner
+        *errors.errorString: inner
+  plain -> (Any) plain
+`
+	if !strings.Contains(out.String(), expected) {
+		t.Fatalf("incorrect error chain printout:\n%s", out.String())
+	}
+}
@@ -0,0 +1,199 @@
+package slogassert
+
+import (
+	"fmt"
+	"strings"
+)
+
+// errorfer is implemented by testing.TB, and allows failing a test
+// from a goroutine other than the one running the test.
+type errorfer interface {
+	Errorf(string, ...any)
+}
+
+// An Expectation is a declaration, made before the code under test
+// runs, that records matching a LogMessageMatch will be logged a
+// certain number of times. It is created by [Handler.Expect].
+//
+// Records matching an Expectation are consumed as they arrive, and
+// never need to be asserted. If a record arrives that would exceed
+// the number of times an Expectation allows, the test fails
+// immediately, with the stack trace of the log call, rather than at
+// the end of the test.
+//
+// The methods on an Expectation modify it in place and return it, for
+// chaining.
+type Expectation struct {
+	root  *Handler
+	match LogMessageMatch
+	min   int
+	// -1 for unbounded
+	max   int
+	count int
+}
+
+// Expect declares that records matching the given LogMessageMatch
+// are expected to be logged. By default, exactly one such record is
+// expected; use the methods on the returned Expectation to change
+// that.
+//
+// Expectations are checked in the order they are declared. A record
+// is consumed by the first Expectation it matches that has not yet
+// been fully satisfied; if it only matches Expectations that have
+// been fully satisfied, it fails the test.
+//
+// Whether all expectations have been met is checked by
+// [Handler.AssertExpectations]. If the Tester has a Cleanup method,
+// as *testing.T does, that will automatically be called during
+// cleanup.
+func (h *Handler) Expect(lmm LogMessageMatch) *Expectation {
+	h.t.Helper()
+	h.validate(lmm)
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	e := &Expectation{root: root, match: lmm, min: 1, max: 1}
+	root.expectations = append(root.expectations, e)
+
+	c, isCleanuper := root.t.(cleanuper)
+	if isCleanuper && !root.expectCleanup {
+		root.expectCleanup = true
+		c.Cleanup(root.AssertExpectations)
+	}
+
+	return e
+}
+
+// Times sets the Expectation to expect exactly n matching records.
+func (e *Expectation) Times(n int) *Expectation {
+	e.root.m.Lock()
+	defer e.root.m.Unlock()
+
+	e.min = n
+	e.max = n
+	return e
+}
+
+// AtLeast sets the Expectation to expect at least n matching
+// records, with no upper bound.
+func (e *Expectation) AtLeast(n int) *Expectation {
+	e.root.m.Lock()
+	defer e.root.m.Unlock()
+
+	e.min = n
+	e.max = -1
+	return e
+}
+
+// AtMost sets the Expectation to expect at most n matching records,
+// with no lower bound.
+func (e *Expectation) AtMost(n int) *Expectation {
+	e.root.m.Lock()
+	defer e.root.m.Unlock()
+
+	e.min = 0
+	e.max = n
+	return e
+}
+
+// Never sets the Expectation to expect no matching records at all;
+// any matching record fails the test immediately.
+func (e *Expectation) Never() *Expectation {
+	return e.Times(0)
+}
+
+// Count returns the number of records that have matched the
+// Expectation so far.
+func (e *Expectation) Count() int {
+	e.root.m.Lock()
+	defer e.root.m.Unlock()
+
+	return e.count
+}
+
+// should be run only under handler lock
+func (e *Expectation) String() string {
+	switch {
+	case e.max == -1:
+		return fmt.Sprintf("%s at least %d time(s)", e.match, e.min)
+	case e.min == e.max:
+		return fmt.Sprintf("%s exactly %d time(s)", e.match, e.min)
+	default:
+		return fmt.Sprintf("%s at most %d time(s)", e.match, e.max)
+	}
+}
+
+// should be run only under handler lock
+func (e *Expectation) saturated() bool {
+	return e.max != -1 && e.count >= e.max
+}
+
+// expected checks the log message against the expectations,
+// returning whether it was consumed by one, and if it exceeded an
+// expectation, a description of the failure.
+//
+// should be run only under handler lock
+func (h *Handler) expected(lm LogMessage) (bool, string) {
+	var exceeded *Expectation
+	for _, e := range h.expectations {
+		if !e.match.Matches(lm) {
+			continue
+		}
+		if e.saturated() {
+			if exceeded == nil {
+				exceeded = e
+			}
+			continue
+		}
+		e.count++
+		return true, ""
+	}
+
+	if exceeded == nil {
+		return false, ""
+	}
+	exceeded.count++
+	return true, fmt.Sprintf(
+		"unexpected log message %q: expected %s, got %d",
+		lm.Message, exceeded, exceeded.count,
+	)
+}
+
+// AssertExpectations asserts that all expectations declared with
+// [Handler.Expect] have been met.
+func (h *Handler) AssertExpectations() {
+	h.t.Helper()
+	root := h.root()
+	root.m.Lock()
+	unmet := []string{}
+	for _, e := range root.expectations {
+		if e.count < e.min {
+			unmet = append(unmet, fmt.Sprintf("  %s, got %d", e, e.count))
+		}
+	}
+	root.m.Unlock()
+
+	if len(unmet) > 0 {
+		h.Fail("%d expectation(s) not met:\n%s",
+			len(unmet), strings.Join(unmet, "\n"))
+	}
+}
+
+// failFromHandle fails the test from within Handle, which may be
+// running in a goroutine other than the test's. If the Tester has
+// an Errorf method, that is used, as calling Fatalf outside of the
+// test's goroutine is not permitted by the testing package.
+func (h *Handler) failFromHandle(msg string, pc uintptr, stacktrace string) {
+	site := callSite(pc)
+	if site != "" {
+		msg = msg + "\nlogged at " + site
+	}
+
+	e, isErrorfer := h.t.(errorfer)
+	if isErrorfer {
+		e.Errorf("%s\n\nstack trace:\n%s", msg, stacktrace)
+		return
+	}
+	h.t.Fatalf("%s\n\nstack trace:\n%s", msg, stacktrace)
+}
@@ -0,0 +1,127 @@
+package slogassert
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// erroringTester is a loggingTester that also supports Errorf.
+type erroringTester struct {
+	loggingTester
+	errors []string
+}
+
+func (et *erroringTester) Errorf(msg string, args ...any) {
+	et.errors = append(et.errors, fmt.Sprintf(msg, args...))
+}
+
+func TestExpect(t *testing.T) {
+	et := &erroringTester{}
+	handler := New(et, slog.LevelInfo, nil)
+	log := slog.New(handler)
+
+	once := handler.Expect(LogMessageMatch{Message: "once", Level: LevelDontCare})
+	twice := handler.Expect(LogMessageMatch{Message: "twice", Level: LevelDontCare}).Times(2)
+	handler.Expect(LogMessageMatch{Message: "many", Level: LevelDontCare}).AtLeast(1)
+	handler.Expect(LogMessageMatch{Message: "never", Level: LevelDontCare}).Never()
+	handler.Expect(LogMessageMatch{Message: "maybe", Level: LevelDontCare}).AtMost(1)
+
+	if len(et.cleanups) != 1 {
+		t.Fatal("expectations not registered for cleanup")
+	}
+
+	log.Info("once")
+	log.Info("twice")
+	log.Info("twice")
+	for i := 0; i < 5; i++ {
+		log.Info("many")
+	}
+	log.Info("unrelated")
+
+	if len(et.errors) != 0 {
+		t.Fatalf("unexpected immediate failures: %v", et.errors)
+	}
+	if once.Count() != 1 || twice.Count() != 2 {
+		t.Fatal("incorrect counts")
+	}
+
+	// expected records are consumed; the unrelated one is not
+	handler.AssertMessage("unrelated")
+	handler.AssertEmpty()
+
+	handler.AssertExpectations()
+	if len(et.failures) != 0 {
+		t.Fatalf("expectations not met: %v", et.failures)
+	}
+
+	log.Info("never")
+	if len(et.errors) != 1 ||
+		!strings.Contains(et.errors[0], `unexpected log message "never"`) ||
+		!strings.Contains(et.errors[0], "exactly 0 time(s), got 1") ||
+		!strings.Contains(et.errors[0], "expect_test.go") {
+		t.Fatalf("incorrect immediate failure: %v", et.errors)
+	}
+
+	log.Info("twice")
+	if len(et.errors) != 2 {
+		t.Fatal("exceeding Times did not fail")
+	}
+
+	et.runCleanups()
+	if len(et.failures) != 0 {
+		t.Fatalf("exceeded expectations failed at cleanup: %v", et.failures)
+	}
+}
+
+func TestExpectUnmet(t *testing.T) {
+	rt := &recordingTester{}
+	handler := New(rt, slog.LevelInfo, nil)
+	handler.Expect(LogMessageMatch{Message: "once", Level: LevelDontCare})
+	handler.Expect(LogMessageMatch{Message: "many", Level: LevelDontCare}).AtLeast(2)
+	slog.New(handler).Info("many")
+
+	handler.AssertExpectations()
+	if len(rt.failures) != 1 ||
+		!strings.Contains(rt.failures[0], "2 expectation(s) not met") ||
+		!strings.Contains(rt.failures[0], `message "many" at least 2 time(s), got 1`) {
+		t.Fatalf("incorrect failures: %v", rt.failures)
+	}
+
+	// without Errorf, immediate failures go through Fatalf
+	handler.Expect(LogMessageMatch{Message: "never", Level: LevelDontCare}).Never()
+	slog.New(handler).Info("never")
+	if len(rt.failures) != 2 {
+		t.Fatalf("incorrect failures: %v", rt.failures)
+	}
+}
+
+// panickingMatcher panics the first time it is used.
+type panickingMatcher struct {
+	panicked bool
+}
+
+func (pm *panickingMatcher) MatchValue(slog.Value) error {
+	if !pm.panicked {
+		pm.panicked = true
+		panic("matcher panicked")
+	}
+	return nil
+}
+
+func TestExpectMatcherPanic(t *testing.T) {
+	rt := &recordingTester{}
+	handler := New(rt, slog.LevelInfo, nil)
+	handler.Expect(Msg("x").Attr("o", &panickingMatcher{}))
+	log := slog.New(handler)
+
+	panics(t, "matcher", func() { log.Info("x", "o", 1) })
+
+	// the handler must not have been left locked
+	log.Info("x", "o", 1)
+	handler.AssertExpectations()
+	if len(rt.failures) != 0 {
+		t.Fatalf("incorrect failures: %v", rt.failures)
+	}
+}
@@ -0,0 +1,130 @@
+package slogassert
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// ErrInjectedFault is a convenient error to use as the Err of a
+// Fault.
+var ErrInjectedFault = errors.New("slogassert: injected fault")
+
+// A Fault describes a failure to inject into the Handler's Handle
+// method, for testing how code wrapping or calling the Handler
+// behaves when logging fails.
+//
+// The trigger fields Nth, Match, and Probability select which
+// records the Fault applies to. All of the trigger fields that are
+// set must be satisfied for the Fault to trigger; a Fault with none
+// of them set triggers on every record.
+//
+// Nth, if nonzero, triggers on only the Nth record (counting from 1)
+// that satisfies Match since the Fault was injected.
+//
+// Match, if not nil, restricts the Fault to records that match it.
+//
+// Probability, if nonzero, triggers the Fault randomly with the given
+// probability, using a random number generator seeded with Seed, so
+// that the sequence of failures is reproducible.
+//
+// When a Fault triggers, the Handle call first sleeps for Latency. If
+// Panic is non-nil, Handle will then panic with that value;
+// otherwise, Handle returns Err. A Fault with a nil Err and nil Panic
+// only adds latency, and the record is otherwise handled normally.
+//
+// Records that fail through Err or Panic are not recorded as log
+// messages and are not passed to any wrapped handler. They are
+// instead recorded to be retrieved by [Handler.Faulted].
+type Fault struct {
+	Nth         int
+	Match       *LogMessageMatch
+	Probability float64
+	Seed        int64
+
+	Latency time.Duration
+	Err     error
+	Panic   any
+}
+
+type injectedFault struct {
+	Fault
+	seen int
+	rand *rand.Rand
+}
+
+// should be run only under handler lock
+func (f *injectedFault) triggers(lm LogMessage) bool {
+	if f.Match != nil && !f.Match.Matches(lm) {
+		return false
+	}
+	f.seen++
+	if f.Nth != 0 && f.seen != f.Nth {
+		return false
+	}
+	if f.Probability != 0 && f.rand.Float64() >= f.Probability {
+		return false
+	}
+	return true
+}
+
+func (f *injectedFault) fails() bool {
+	return f.Err != nil || f.Panic != nil
+}
+
+// InjectFault adds the given Fault to the Handler. Faults are checked
+// in the order they are injected, and the first one to trigger for a
+// given record is the one used. Every Fault still counts the record
+// towards its Nth, even if an earlier Fault is used for it.
+//
+// Faults apply to the root Handler, and thus to all Handlers derived
+// from it via WithAttrs and WithGroup.
+func (h *Handler) InjectFault(fault Fault) {
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	root.faults = append(root.faults, &injectedFault{
+		Fault: fault,
+		rand:  rand.New(rand.NewSource(fault.Seed)),
+	})
+}
+
+// ClearFaults removes all injected faults from the Handler. It does
+// not clear the record of the messages that have already been
+// failed.
+func (h *Handler) ClearFaults() {
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	root.faults = nil
+}
+
+// Faulted returns the log messages that were failed by an injected
+// Fault, in the order they were failed. The returned result is a
+// deep copy.
+func (h *Handler) Faulted() []LogMessage {
+	msgs := []LogMessage{}
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	for _, msg := range root.faulted {
+		msgs = append(msgs, msg.clone())
+	}
+	return msgs
+}
+
+// should be run only under handler lock
+func (h *Handler) findFault(lm LogMessage) *injectedFault {
+	// every fault sees every record, so that each counts its Nth
+	// record and draws its probability independently of the others
+	var found *injectedFault
+	for _, fault := range h.faults {
+		if fault.triggers(lm) && found == nil {
+			found = fault
+		}
+	}
+	return found
+}
@@ -0,0 +1,153 @@
+package slogassert
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestFaultNth(t *testing.T) {
+	handler := New(t, slog.LevelWarn, nil)
+	defer handler.AssertEmpty()
+	handler.InjectFault(Fault{Nth: 2, Err: ErrInjectedFault})
+
+	ctx := context.Background()
+	record := func(msg string) slog.Record {
+		return slog.NewRecord(time.Now(), slog.LevelWarn, msg, 0)
+	}
+
+	if handler.Handle(ctx, record("one")) != nil {
+		t.Fatal("first record failed")
+	}
+	err := handler.WithGroup("g").Handle(ctx, record("two"))
+	if !errors.Is(err, ErrInjectedFault) {
+		t.Fatalf("second record did not fail: %v", err)
+	}
+	if handler.Handle(ctx, record("three")) != nil {
+		t.Fatal("third record failed")
+	}
+
+	faulted := handler.Faulted()
+	if len(faulted) != 1 || faulted[0].Message != "two" {
+		t.Fatalf("incorrect faulted messages: %#v", faulted)
+	}
+
+	handler.AssertMessage("one")
+	handler.AssertMessage("three")
+}
+
+func TestFaultNthIndependent(t *testing.T) {
+	handler := New(t, slog.LevelWarn, nil)
+	defer handler.AssertEmpty()
+	first := errors.New("first")
+	second := errors.New("second")
+	handler.InjectFault(Fault{Nth: 1, Err: first})
+	handler.InjectFault(Fault{Nth: 2, Err: second})
+
+	ctx := context.Background()
+	for idx, expected := range []error{first, second, nil} {
+		err := handler.Handle(ctx,
+			slog.NewRecord(time.Now(), slog.LevelWarn, testWarning, 0))
+		if err != expected {
+			t.Fatalf("record %d: expected %v, got %v", idx+1, expected, err)
+		}
+	}
+	handler.AssertMessage(testWarning)
+}
+
+func TestFaultMatch(t *testing.T) {
+	handler := New(t, slog.LevelWarn, nil)
+	defer handler.AssertEmpty()
+	myErr := errors.New("my error")
+	handler.InjectFault(Fault{
+		Match: &LogMessageMatch{
+			Message: testWarning,
+			Level:   LevelDontCare,
+			Attrs:   map[string]any{"fail": true},
+		},
+		Err: myErr,
+	})
+
+	ctx := context.Background()
+	record := slog.NewRecord(time.Now(), slog.LevelWarn, testWarning, 0)
+	if handler.Handle(ctx, record) != nil {
+		t.Fatal("unmatched record failed")
+	}
+	record.AddAttrs(slog.Bool("fail", true))
+	if handler.Handle(ctx, record) != myErr {
+		t.Fatal("matched record did not fail")
+	}
+
+	handler.AssertPrecise(LogMessageMatch{
+		Message:       testWarning,
+		Level:         slog.LevelWarn,
+		AllAttrsMatch: true,
+	})
+	if len(handler.Faulted()) != 1 {
+		t.Fatal("incorrect faulted messages")
+	}
+
+	handler.ClearFaults()
+	if handler.Handle(ctx, record) != nil {
+		t.Fatal("cleared fault still triggered")
+	}
+	handler.AssertMessage(testWarning)
+}
+
+func TestFaultRandom(t *testing.T) {
+	failures := func() []bool {
+		handler := New(t, slog.LevelWarn, nil)
+		handler.InjectFault(Fault{
+			Probability: 0.5,
+			Seed:        12,
+			Err:         ErrInjectedFault,
+		})
+		results := []bool{}
+		for i := 0; i < 20; i++ {
+			record := slog.NewRecord(time.Now(), slog.LevelWarn, testWarning, 0)
+			err := handler.Handle(context.Background(), record)
+			results = append(results, err != nil)
+		}
+		handler.Reset()
+		return results
+	}
+
+	first := failures()
+	second := failures()
+	failed := 0
+	for idx := range first {
+		if first[idx] != second[idx] {
+			t.Fatal("seeded faults are not reproducible")
+		}
+		if first[idx] {
+			failed++
+		}
+	}
+	if failed == 0 || failed == 20 {
+		t.Fatalf("implausible number of random failures: %d", failed)
+	}
+}
+
+func TestFaultLatencyAndPanic(t *testing.T) {
+	handler := New(t, slog.LevelWarn, nil)
+	defer handler.AssertEmpty()
+	log := slog.New(handler)
+
+	handler.InjectFault(Fault{Latency: 10 * time.Millisecond})
+	start := time.Now()
+	log.Warn(testWarning)
+	if time.Since(start) < 10*time.Millisecond {
+		t.Fatal("latency not injected")
+	}
+	// latency-only faults still record the message
+	handler.AssertMessage(testWarning)
+
+	handler.ClearFaults()
+	handler.InjectFault(Fault{Panic: "boom"})
+	panics(t, "injected panic", func() { log.Warn(testWarning) })
+	if len(handler.Faulted()) != 1 {
+		t.Fatal("panicking fault not recorded")
+	}
+}
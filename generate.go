@@ -0,0 +1,262 @@
+package slogassert
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GenerateAssertions writes Go source code to the given writer that
+// would assert all of the currently unasserted log messages, as a
+// series of handler.AssertPrecise calls.
+//
+// This does not assert the messages. It is intended as a starting
+// point when writing tests for existing code that logs a lot; paste
+// the output into your test and then edit it down to what you really
+// care about.
+func (h *Handler) GenerateAssertions(w io.Writer) error {
+	h.t.Helper()
+	return WriteAssertions(w, h.Unasserted())
+}
+
+// WriteAssertions writes Go source code to the given writer that
+// would assert the given log messages, as a series of
+// handler.AssertPrecise calls. See [Handler.GenerateAssertions].
+//
+// Attribute values are written as literals of the type appropriate
+// for their slog.Kind. KindAny values are written with %#v, which may
+// require some hand-editing to compile.
+func WriteAssertions(w io.Writer, msgs []LogMessage) error {
+	src := &bytes.Buffer{}
+	for _, lm := range msgs {
+		writeAssertion(src, lm)
+	}
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		// If this failed, it's almost certainly because of a
+		// KindAny value that %#v could not represent as
+		// source. Give the user the unformatted source so they
+		// can fix it up by hand rather than nothing at all.
+		formatted = src.Bytes()
+	}
+
+	_, werr := w.Write(formatted)
+	if werr != nil {
+		return werr
+	}
+	return err
+}
+
+func writeAssertion(w *bytes.Buffer, lm LogMessage) {
+	w.WriteString("handler.AssertPrecise(slogassert.LogMessageMatch{\n")
+	w.WriteString("Message: ")
+	w.WriteString(strconv.Quote(lm.Message))
+	w.WriteString(",\nLevel: ")
+	w.WriteString(levelLiteral(lm.Level))
+	w.WriteString(",\n")
+
+	if len(lm.Attrs) > 0 {
+		w.WriteString("Attrs: map[string]any{\n")
+		for _, key := range sortedKeys(lm.Attrs) {
+			w.WriteString(strconv.Quote(key))
+			w.WriteString(": ")
+			w.WriteString(valueLiteral(lm.Attrs[key]))
+			w.WriteString(",\n")
+		}
+		w.WriteString("},\n")
+	}
+
+	w.WriteString("AllAttrsMatch: true,\n")
+	w.WriteString("})\n")
+}
+
+func levelLiteral(level slog.Level) string {
+	switch level {
+	case slog.LevelDebug:
+		return "slog.LevelDebug"
+	case slog.LevelInfo:
+		return "slog.LevelInfo"
+	case slog.LevelWarn:
+		return "slog.LevelWarn"
+	case slog.LevelError:
+		return "slog.LevelError"
+	default:
+		return fmt.Sprintf("slog.Level(%d)", int(level))
+	}
+}
+
+func valueLiteral(val slog.Value) string {
+	switch val.Kind() {
+	case slog.KindBool:
+		return strconv.FormatBool(val.Bool())
+	case slog.KindDuration:
+		return fmt.Sprintf("time.Duration(%d)", int64(val.Duration()))
+	case slog.KindFloat64:
+		return fmt.Sprintf("float64(%s)",
+			strconv.FormatFloat(val.Float64(), 'g', -1, 64))
+	case slog.KindInt64:
+		// matchAttr accepts a bare int for KindInt64.
+		return strconv.FormatInt(val.Int64(), 10)
+	case slog.KindString:
+		return strconv.Quote(val.String())
+	case slog.KindTime:
+		// matchAttr compares times with time.Equal, so
+		// normalizing to UTC is safe.
+		t := val.Time().UTC()
+		return fmt.Sprintf("time.Date(%d, time.%s, %d, %d, %d, %d, %d, time.UTC)",
+			t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(),
+			t.Second(), t.Nanosecond())
+	case slog.KindUint64:
+		return fmt.Sprintf("uint64(%d)", val.Uint64())
+	case slog.KindLogValuer:
+		return valueLiteral(val.Resolve())
+	default:
+		v := val.Any()
+		if err, isErr := v.(error); isErr {
+			return fmt.Sprintf(
+				"func(v any) bool { err, _ := v.(error); return err != nil && err.Error() == %s }",
+				strconv.Quote(err.Error()),
+			)
+		}
+		return fmt.Sprintf("%#v", v)
+	}
+}
+
+// ReadJSONLog reads log output as produced by slog.JSONHandler, one
+// JSON object per line, and converts it into LogMessages, so that it
+// may be passed to [WriteAssertions].
+//
+// JSON can not represent all the slog.Kinds, so the result is
+// approximate: integral numbers become KindInt64, other numbers
+// become KindFloat64, nested objects are treated as groups, and
+// arrays and nulls become KindAny. Times and durations will come
+// through as whatever the JSONHandler rendered them as.
+//
+// The slog.TimeKey, slog.LevelKey, slog.MessageKey, and
+// slog.SourceKey top-level keys are handled specially. Blank lines
+// are skipped.
+func ReadJSONLog(r io.Reader) ([]LogMessage, error) {
+	msgs := []LogMessage{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16*1024*1024)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		lm, err := parseJSONLogLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		msgs = append(msgs, lm)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+var errNotJSONObject = errors.New("log line is not a JSON object")
+
+func parseJSONLogLine(line []byte) (LogMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+
+	var raw any
+	err := dec.Decode(&raw)
+	if err != nil {
+		return LogMessage{}, err
+	}
+	obj, isObj := raw.(map[string]any)
+	if !isObj {
+		return LogMessage{}, errNotJSONObject
+	}
+
+	lm := LogMessage{
+		Level: slog.LevelInfo,
+		Attrs: map[string]slog.Value{},
+	}
+
+	for key, val := range obj {
+		switch key {
+		case slog.MessageKey:
+			lm.Message = fmt.Sprint(val)
+			delete(obj, key)
+		case slog.LevelKey:
+			err := lm.Level.UnmarshalText([]byte(fmt.Sprint(val)))
+			if err != nil {
+				return LogMessage{}, err
+			}
+			delete(obj, key)
+		case slog.TimeKey:
+			s, isString := val.(string)
+			if isString {
+				t, err := time.Parse(time.RFC3339Nano, s)
+				if err == nil {
+					lm.Time = t
+				}
+			}
+			delete(obj, key)
+		case slog.SourceKey:
+			delete(obj, key)
+		}
+	}
+
+	flattenJSON(lm.Attrs, nil, obj)
+	return lm, nil
+}
+
+func flattenJSON(
+	attrs map[string]slog.Value,
+	group []string,
+	obj map[string]any,
+) {
+	for key, val := range obj {
+		switch v := val.(type) {
+		case map[string]any:
+			newGroups := append(append([]string{}, group...), key)
+			flattenJSON(attrs, newGroups, v)
+		default:
+			attrs[encgroups(group, key)] = jsonValue(v)
+		}
+	}
+}
+
+func jsonValue(val any) slog.Value {
+	switch v := val.(type) {
+	case string:
+		return slog.StringValue(v)
+	case bool:
+		return slog.BoolValue(v)
+	case json.Number:
+		s := v.String()
+		if !strings.ContainsAny(s, ".eE") {
+			i, err := v.Int64()
+			if err == nil {
+				return slog.Int64Value(i)
+			}
+			u, err := strconv.ParseUint(s, 10, 64)
+			if err == nil {
+				return slog.Uint64Value(u)
+			}
+		}
+		f, _ := v.Float64()
+		return slog.Float64Value(f)
+	default:
+		return slog.AnyValue(v)
+	}
+}
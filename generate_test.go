@@ -0,0 +1,126 @@
+package slogassert
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateAssertions(t *testing.T) {
+	handler := New(t, slog.LevelDebug, nil)
+	defer handler.AssertEmpty()
+	log := slog.New(handler)
+
+	log.WithGroup("req").Info("request",
+		"url", "/url",
+		"status", 200,
+		"size", uint64(10),
+		"ratio", 0.5,
+		"ok", true,
+		"duration", time.Second,
+		"at", time.Date(2024, time.July, 25, 1, 2, 3, 4, time.UTC),
+		"a.b", "dotted",
+		"err", errors.New("oops"),
+	)
+	log.Log(context.Background(), slog.LevelWarn+2, "odd level")
+
+	buf := &strings.Builder{}
+	err := handler.GenerateAssertions(buf)
+	if err != nil {
+		t.Fatalf("could not generate assertions: %v", err)
+	}
+
+	expected := `handler.AssertPrecise(slogassert.LogMessageMatch{
+	Message: "request",
+	Level:   slog.LevelInfo,
+	Attrs: map[string]any{
+		"req.a\\.b":    "dotted",
+		"req.at":       time.Date(2024, time.July, 25, 1, 2, 3, 4, time.UTC),
+		"req.duration": time.Duration(1000000000),
+		"req.err":      func(v any) bool { err, _ := v.(error); return err != nil && err.Error() == "oops" },
+		"req.ok":       true,
+		"req.ratio":    float64(0.5),
+		"req.size":     uint64(10),
+		"req.status":   200,
+		"req.url":      "/url",
+	},
+	AllAttrsMatch: true,
+})
+handler.AssertPrecise(slogassert.LogMessageMatch{
+	Message:       "odd level",
+	Level:         slog.Level(6),
+	AllAttrsMatch: true,
+})
+`
+	if buf.String() != expected {
+		t.Fatalf("unexpected generated code:\n%s", buf.String())
+	}
+
+	// generation does not consume the messages
+	if len(handler.Unasserted()) != 2 {
+		t.Fatal("GenerateAssertions consumed messages")
+	}
+	handler.Reset()
+}
+
+func TestReadJSONLog(t *testing.T) {
+	buf := &strings.Builder{}
+	log := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		AddSource: true,
+		Level:     slog.LevelDebug,
+	}))
+
+	log.WithGroup("req").Warn("request",
+		"url", "/url",
+		"status", 200,
+		"ratio", 0.5,
+		"big", uint64(1<<63),
+		"ok", true,
+		"list", []int{1},
+	)
+	buf.WriteString("\n")
+	log.Debug("second")
+
+	msgs, err := ReadJSONLog(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("could not read JSON log: %v", err)
+	}
+	if len(msgs) != 2 {
+		t.Fatalf("incorrect number of messages read: %d", len(msgs))
+	}
+
+	match := LogMessageMatch{
+		Message: "request",
+		Level:   slog.LevelWarn,
+		Attrs: map[string]any{
+			"req.url":    "/url",
+			"req.status": 200,
+			"req.ratio":  0.5,
+			"req.big":    uint64(1 << 63),
+			"req.ok":     true,
+			"req.list":   func(any) bool { return true },
+		},
+		AllAttrsMatch: true,
+	}
+	if !match.Matches(msgs[0]) {
+		t.Fatalf("JSON log not read correctly: %#v", msgs[0])
+	}
+	if msgs[0].Time.IsZero() {
+		t.Fatal("did not read the time")
+	}
+	if msgs[1].Message != "second" || msgs[1].Level != slog.LevelDebug {
+		t.Fatal("did not read the second message correctly")
+	}
+
+	_, err = ReadJSONLog(strings.NewReader("[]\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Fatalf("incorrect error for non-object: %v", err)
+	}
+	_, err = ReadJSONLog(strings.NewReader(`{"level": "LOUD"}`))
+	if err == nil {
+		t.Fatal("incorrect error for bad level")
+	}
+}
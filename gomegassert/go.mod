@@ -0,0 +1,17 @@
+module github.com/thejerf/slogassert/gomegassert
+
+go 1.21
+
+require (
+	github.com/onsi/gomega v1.33.1
+	github.com/thejerf/slogassert v0.0.0-00010101000000-000000000000
+)
+
+require (
+	github.com/google/go-cmp v0.6.0 // indirect
+	golang.org/x/net v0.24.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/thejerf/slogassert => ../
@@ -0,0 +1,163 @@
+/*
+Package gomegassert provides Gomega matchers for a [slogassert.Handler],
+so that log assertions in Ginkgo/Gomega suites fail through Gomega
+rather than calling the Handler's Tester:
+
+	Expect(handler).To(gomegassert.HaveLogged(slogassert.Msg("started")))
+	Expect(handler).To(gomegassert.HaveLoggedExactly(3,
+	     slogassert.Msg("retrying")))
+	gomegassert.EventuallyLog(handler, slogassert.Msg("done"))
+
+The matchers assert the log messages they match, with the same
+semantics as the corresponding Handler methods. Handler.AssertEmpty
+should still be used to ensure all messages are accounted for,
+usually by creating the Handler with GinkgoT().
+
+This is a separate module so that slogassert itself does not depend
+on Gomega.
+*/
+package gomegassert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	"github.com/thejerf/slogassert"
+)
+
+type loggedMatcher struct {
+	name  string
+	match slogassert.LogMessageMatch
+	// if true, only assert the first match, as AssertPrecise
+	first bool
+	count int
+
+	found   int
+	matched []slogassert.LogMessage
+}
+
+// HaveLogged succeeds if the *slogassert.Handler has an unasserted
+// log message matching the LogMessageMatch, and asserts the first
+// such message, as Handler.AssertPrecise does.
+func HaveLogged(lmm slogassert.LogMessageMatch) types.GomegaMatcher {
+	return &loggedMatcher{name: "HaveLogged", match: lmm, first: true, count: 1}
+}
+
+// HaveLoggedExactly succeeds if the *slogassert.Handler has exactly n
+// unasserted log messages matching the LogMessageMatch, and then
+// asserts them. Unlike Handler.AssertCount, nothing is asserted if
+// there are not n of them, so this may be polled with Eventually
+// while the messages arrive.
+func HaveLoggedExactly(n int, lmm slogassert.LogMessageMatch) types.GomegaMatcher {
+	return &loggedMatcher{name: "HaveLoggedExactly", match: lmm, count: n}
+}
+
+// EventuallyLog uses the global Gomega to wait until the handler has
+// logged a message matching the LogMessageMatch, asserting it. The
+// intervals are passed to Eventually, and it returns whether the
+// message was logged in time.
+func EventuallyLog(
+	handler *slogassert.Handler,
+	lmm slogassert.LogMessageMatch,
+	intervals ...any,
+) bool {
+	return gomega.EventuallyWithOffset(1, handler, intervals...).
+		Should(HaveLogged(lmm))
+}
+
+// Match counts the matching messages without asserting them, as
+// Gomega calls it on every poll of Eventually and Consistently. Only
+// once the match succeeds are the matching messages asserted.
+func (lm *loggedMatcher) Match(actual any) (bool, error) {
+	handler, isHandler := actual.(*slogassert.Handler)
+	if !isHandler {
+		return false, fmt.Errorf("%s expects a *slogassert.Handler, got %T",
+			lm.name, actual)
+	}
+	err := lm.match.Validate()
+	if err != nil {
+		return false, err
+	}
+
+	var invalid error
+	lm.matched = nil
+	for _, msg := range handler.Unasserted() {
+		err := lm.match.MatchError(msg)
+		var ime *slogassert.InvalidMatcherError
+		if errors.As(err, &ime) && invalid == nil {
+			invalid = err
+		}
+		if err == nil {
+			lm.matched = append(lm.matched, msg)
+		}
+	}
+	lm.found = len(lm.matched)
+
+	if lm.found == 0 && invalid != nil {
+		return false, invalid
+	}
+	if lm.first {
+		if lm.found == 0 {
+			return false, nil
+		}
+		lm.matched = lm.matched[:1]
+	} else if lm.found != lm.count {
+		return false, nil
+	}
+
+	// messages may have arrived since, so only assert as many as
+	// were counted
+	asserted := 0
+	handler.Assert(func(msg slogassert.LogMessage) bool {
+		if asserted == len(lm.matched) || !lm.match.Matches(msg) {
+			return false
+		}
+		asserted++
+		return true
+	})
+	return true, nil
+}
+
+func (lm *loggedMatcher) FailureMessage(actual any) string {
+	msg := &strings.Builder{}
+	if lm.first {
+		fmt.Fprintf(msg, "Expected handler to have logged %s", lm.match)
+	} else {
+		fmt.Fprintf(msg, "Expected handler to have logged %s exactly %d time(s), found %d",
+			lm.match, lm.count, lm.found)
+	}
+	writeUnasserted(msg, actual)
+	return msg.String()
+}
+
+func (lm *loggedMatcher) NegatedFailureMessage(_ any) string {
+	msg := &strings.Builder{}
+	if lm.first {
+		fmt.Fprintf(msg, "Expected handler not to have logged %s", lm.match)
+	} else {
+		fmt.Fprintf(msg, "Expected handler not to have logged %s exactly %d time(s)",
+			lm.match, lm.count)
+	}
+	// the matching messages have been asserted by Match, so they
+	// are printed from what it saw
+	fmt.Fprintf(msg, "\n%d matching log message(s):\n", len(lm.matched))
+	for _, matched := range lm.matched {
+		matched.Print(msg)
+	}
+	return msg.String()
+}
+
+func writeUnasserted(msg *strings.Builder, actual any) {
+	handler, isHandler := actual.(*slogassert.Handler)
+	if !isHandler {
+		return
+	}
+	unasserted := handler.Unasserted()
+	fmt.Fprintf(msg, "\n%d unasserted log message(s):\n", len(unasserted))
+	for _, lm := range unasserted {
+		lm.Print(msg)
+	}
+}
@@ -0,0 +1,95 @@
+package gomegassert
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/thejerf/slogassert"
+)
+
+func TestMatchers(t *testing.T) {
+	g := gomega.NewWithT(t)
+	handler := slogassert.New(t, slog.LevelInfo, nil)
+	defer handler.AssertEmpty()
+	log := slog.New(handler)
+
+	log.Info("started", "port", 80)
+	log.Info("retrying")
+	log.Info("retrying")
+	log.Info("retrying")
+
+	g.Expect(handler).To(HaveLogged(slogassert.Msg("started").Attr("port", 80)))
+	g.Expect(handler).NotTo(HaveLogged(slogassert.Msg("started")))
+	g.Expect(handler).To(HaveLoggedExactly(3, slogassert.Msg("retrying")))
+	g.Expect(handler).To(HaveLoggedExactly(0, slogassert.Msg("retrying")))
+}
+
+func TestEventuallyLog(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	handler := slogassert.New(t, slog.LevelInfo, nil)
+	defer handler.AssertEmpty()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		slog.New(handler).Info("done")
+	}()
+
+	if !EventuallyLog(handler, slogassert.Msg("done"), time.Second, time.Millisecond) {
+		t.Fatal("EventuallyLog did not see the message")
+	}
+}
+
+func TestFailures(t *testing.T) {
+	failures := []string{}
+	g := gomega.NewGomega(func(message string, _ ...int) {
+		failures = append(failures, message)
+	})
+	handler := slogassert.New(t, slog.LevelInfo, nil)
+	defer handler.AssertEmpty()
+	log := slog.New(handler)
+
+	log.Info("retrying", "count", 1)
+	log.Info("retrying", "count", 2)
+
+	g.Expect(handler).To(HaveLogged(slogassert.Msg("started")))
+	g.Expect(handler).To(HaveLogged(slogassert.Msg("retrying").Attr("count", "1")))
+	g.Expect(handler).To(HaveLoggedExactly(1, slogassert.Msg("retrying")))
+	g.Expect("handler").To(HaveLogged(slogassert.Msg("retrying")))
+	log.Info("started")
+	g.Expect(handler).NotTo(HaveLogged(slogassert.Msg("started")))
+
+	if len(failures) != 5 ||
+		!strings.HasPrefix(failures[0], `Expected handler to have logged message "started"`) ||
+		!strings.Contains(failures[0], "2 unasserted log message(s):") ||
+		failures[1] != "attr `count`: matcher of type string cannot compare KindInt64" ||
+		!strings.HasPrefix(failures[2], `Expected handler to have logged message "retrying" exactly 1 time(s), found 2`) ||
+		failures[3] != "HaveLogged expects a *slogassert.Handler, got string" ||
+		!strings.HasPrefix(failures[4], `Expected handler not to have logged message "started"
+1 matching log message(s):`) {
+		t.Fatalf("incorrect failures: %#v", failures)
+	}
+
+	// failing matches do not assert anything
+	handler.AssertCount(slogassert.Msg("retrying").Exactly(2))
+}
+
+func TestEventuallyLoggedExactly(t *testing.T) {
+	g := gomega.NewWithT(t)
+	handler := slogassert.New(t, slog.LevelInfo, nil)
+	defer handler.AssertEmpty()
+
+	go func() {
+		log := slog.New(handler)
+		for i := 0; i < 3; i++ {
+			time.Sleep(5 * time.Millisecond)
+			log.Info("tick")
+		}
+	}()
+
+	// each poll that sees fewer than 3 must leave them for the next
+	g.Eventually(handler, time.Second, time.Millisecond).
+		Should(HaveLoggedExactly(3, slogassert.Msg("tick")))
+}
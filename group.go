@@ -0,0 +1,189 @@
+package slogassert
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+// A GroupMatch matches the contents of a group as a whole. It may be
+// used as the matcher for a group's key in a LogMessageMatch's Attrs,
+// or nested in another GroupMatch's Attrs.
+//
+// The keys of the Attrs are relative to the group, so a GroupMatch
+// for the key "request" with Attrs of {"url": ...} will match the
+// "request.url" attribute. The values are matchers just as in
+// LogMessageMatch.Attrs, including further GroupMatches or nested
+// map[string]any values for subgroups. The group must hold at least
+// one attribute in the message for it to match, even if Attrs is
+// empty.
+//
+// AllAttrsMatch works as it does in LogMessageMatch, but only for
+// the attributes in this group, so each group can decide for itself
+// whether it is matched exactly or as a subset. Attributes in a
+// subgroup are accounted for by the subgroup's matcher.
+//
+// A plain map[string]any used as a matcher is equivalent to a
+// GroupMatch with AllAttrsMatch false, unless the message has an
+// attribute with the map's key, in which case the map is compared to
+// that attribute's value as it always was.
+type GroupMatch struct {
+	Attrs         map[string]any
+	AllAttrsMatch bool
+}
+
+// Group returns a GroupMatch that matches a group containing at least
+// the given attributes.
+func Group(attrs map[string]any) GroupMatch {
+	return GroupMatch{Attrs: attrs}
+}
+
+// ExactGroup returns a GroupMatch that matches a group containing
+// exactly the given attributes.
+func ExactGroup(attrs map[string]any) GroupMatch {
+	return GroupMatch{Attrs: attrs, AllAttrsMatch: true}
+}
+
+// groupMatch returns the GroupMatch for a matcher if the matcher is a
+// GroupMatch, or a map[string]any for a key that the message does not
+// hold an attribute for.
+func groupMatch(
+	matcher any,
+	key string,
+	attrs map[string]slog.Value,
+) (GroupMatch, bool) {
+	switch m := matcher.(type) {
+	case GroupMatch:
+		return m, true
+	case map[string]any:
+		_, haveVal := attrs[key]
+		return GroupMatch{Attrs: m}, !haveVal
+	default:
+		return GroupMatch{}, false
+	}
+}
+
+// matchAttrs matches the given attrs against the matchers, with the
+// keys of the matchers relative to the given prefix, which is either
+// empty or an encoded group key ending in a dot. See
+// LogMessageMatch.MatchError for the errors returned.
+//
+// If explain is false, plain mismatches are returned as errNoMatch,
+// and keys are not sorted, so a failure to match does not allocate.
+func matchAttrs(
+	prefix string,
+	matchers map[string]any,
+	allAttrsMatch bool,
+	attrs map[string]slog.Value,
+	strictKinds bool,
+	explain bool,
+) error {
+	groups := []string{}
+
+	err := eachKey(matchers, explain, func(key string) error {
+		fullKey := prefix + key
+		matcher := matchers[key]
+
+		group, isGroup := groupMatch(matcher, fullKey, attrs)
+		if isGroup {
+			if _, haveVal := attrs[fullKey]; haveVal {
+				return explainf(explain, "attr `%s` is not a group", fullKey)
+			}
+			// an empty GroupMatch would otherwise match a record
+			// without the group at all
+			if !hasKeyPrefix(attrs, fullKey+".") {
+				return explainf(explain, "group `%s` is missing", fullKey)
+			}
+			groups = append(groups, fullKey+".")
+			return matchAttrs(fullKey+".", group.Attrs,
+				group.AllAttrsMatch, attrs, strictKinds, explain)
+		}
+
+		val, haveVal := attrs[fullKey]
+		if !haveVal {
+			return explainf(explain, "attr `%s` is missing", fullKey)
+		}
+		err := matchAttrKinds(matcher, val, strictKinds)
+		switch {
+		case err == nil:
+			return nil
+		case !explain && errors.Is(err, errNoMatch):
+			return errNoMatch
+		case errors.Is(err, errNoMatch):
+			return &mismatch{"attr `" + fullKey + "`", err.Error()}
+		default:
+			return &InvalidMatcherError{Key: fullKey, Err: err}
+		}
+	})
+	if err != nil || !allAttrsMatch {
+		return err
+	}
+
+	return eachKey(attrs, explain, func(key string) error {
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		_, matched := matchers[strings.TrimPrefix(key, prefix)]
+		for _, group := range groups {
+			matched = matched || strings.HasPrefix(key, group)
+		}
+		if !matched {
+			return explainf(explain, "attr `%s` is not matched", key)
+		}
+		return nil
+	})
+}
+
+// explainf returns a mismatch with the reason formatted with the key
+// if explain is true, and errNoMatch otherwise. It takes a single
+// string so that not explaining does not allocate.
+func explainf(explain bool, format string, key string) error {
+	if !explain {
+		return errNoMatch
+	}
+	return &mismatch{"", fmt.Sprintf(format, key)}
+}
+
+// hasKeyPrefix returns whether any of the attrs has a key with the
+// given prefix.
+func hasKeyPrefix(attrs map[string]slog.Value, prefix string) bool {
+	for key := range attrs {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateAttrs is LogMessageMatch.Validate for the matchers under the
+// given prefix.
+func validateAttrs(prefix string, matchers map[string]any) error {
+	for _, key := range sortedKeys(matchers) {
+		matcher := matchers[key]
+		switch m := matcher.(type) {
+		case ValueMatcher, slog.LogValuer:
+			continue
+		case GroupMatch:
+			matcher = m.Attrs
+		}
+
+		nested, isNested := matcher.(map[string]any)
+		if isNested {
+			err := validateAttrs(prefix+key+".", nested)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if isFuncMatcher(matcher) && !matcherFuncs[reflect.TypeOf(matcher)] {
+			return &InvalidMatcherError{
+				Key: prefix + key,
+				Err: fmt.Errorf("matcher of type %T cannot compare any slog.Kind", matcher),
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package slogassert
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestGroupMatching(t *testing.T) {
+	handler := New(t, slog.LevelInfo, nil)
+	defer handler.AssertEmpty()
+	log := slog.New(handler)
+
+	logRequest := func() {
+		log.Info("request",
+			slog.Group("request",
+				slog.String("method", "GET"),
+				slog.String("url", "/"),
+				slog.Group("headers",
+					slog.String("accept", "*/*"),
+					slog.String("host", "example.com"),
+				),
+			),
+			slog.Int("status", 200),
+		)
+	}
+
+	lm := LogMessage{
+		Message: "request",
+		Level:   slog.LevelInfo,
+		Attrs: map[string]slog.Value{
+			"request.method":         slog.StringValue("GET"),
+			"request.url":            slog.StringValue("/"),
+			"request.headers.accept": slog.StringValue("*/*"),
+			"request.headers.host":   slog.StringValue("example.com"),
+			"status":                 slog.Int64Value(200),
+		},
+	}
+
+	for _, test := range []struct {
+		lmm    LogMessageMatch
+		reason string
+	}{
+		{Msg("request").Attr("request", map[string]any{"method": "GET"}), ""},
+		{Msg("request").Attr("request", Group(map[string]any{"url": "/"})), ""},
+		{
+			Msg("request").Attr("request", map[string]any{
+				"headers": map[string]any{"host": "example.com"},
+			}),
+			"",
+		},
+		{
+			Msg("request").Attr("request", ExactGroup(map[string]any{
+				"method":  "GET",
+				"url":     "/",
+				"headers": map[string]any{},
+			})),
+			"",
+		},
+		{
+			// the subset subgroup doesn't make its exact parent
+			// fail, nor does the status outside the group
+			Msg("request").Attr("request", ExactGroup(map[string]any{
+				"method":  "GET",
+				"url":     "/",
+				"headers": Group(map[string]any{"accept": "*/*"}),
+			})),
+			"",
+		},
+		{
+			Msg("request").
+				Attr("request", map[string]any{"method": "GET"}).
+				Attr("status", 200).
+				AllAttrs(),
+			"",
+		},
+		{
+			Msg("request").Attr("request", ExactGroup(map[string]any{
+				"method": "GET",
+				"url":    "/",
+			})),
+			"attr `request.headers.accept` is not matched",
+		},
+		{
+			Msg("request").Attr("request", map[string]any{
+				"headers": ExactGroup(map[string]any{"host": "example.com"}),
+			}),
+			"attr `request.headers.accept` is not matched",
+		},
+		{
+			Msg("request").Attr("request", map[string]any{"method": "POST"}),
+			"attr `request.method`: does not match",
+		},
+		{
+			Msg("request").Attr("request", map[string]any{"body": ""}),
+			"attr `request.body` is missing",
+		},
+		{
+			Msg("request").Attr("status", Group(map[string]any{})),
+			"attr `status` is not a group",
+		},
+		{
+			Msg("request").Attr("response", Group(map[string]any{})),
+			"group `response` is missing",
+		},
+		{
+			Msg("request").Attr("response", map[string]any{}),
+			"group `response` is missing",
+		},
+		{
+			Msg("request").Attr("request", map[string]any{
+				"body": ExactGroup(map[string]any{}),
+			}),
+			"group `request.body` is missing",
+		},
+		{
+			Msg("request").Attr("status", 200).AllAttrs(),
+			"attr `request.headers.accept` is not matched",
+		},
+	} {
+		err := test.lmm.MatchError(lm)
+		if test.reason == "" && err != nil {
+			t.Fatalf("%s should match: %v", test.lmm, err)
+		}
+		if test.reason != "" && (err == nil || err.Error() != test.reason) {
+			t.Fatalf("%s: incorrect mismatch: %v", test.lmm, err)
+		}
+	}
+
+	// a map matcher for an attribute that exists still compares the
+	// value itself
+	mapMatch := Msg("m").Attr("m", map[string]any{"a": 1})
+	if !mapMatch.Matches(LogMessage{
+		Message: "m",
+		Attrs:   map[string]slog.Value{"m": slog.AnyValue(map[string]any{"a": 1})},
+	}) {
+		t.Fatal("map matcher no longer matches a map value")
+	}
+
+	err := Msg("request").Attr("request", map[string]any{
+		"headers": map[string]any{"host": func(int) bool { return true }},
+	}).Validate()
+	if err == nil ||
+		err.Error() != "attr `request.headers.host`: matcher of type func(int) bool cannot compare any slog.Kind" {
+		t.Fatalf("nested matchers not validated: %v", err)
+	}
+
+	logRequest()
+	handler.AssertPrecise(Msg("request").
+		Attr("request", ExactGroup(map[string]any{
+			"method":  "GET",
+			"url":     "/",
+			"headers": map[string]any{"host": "example.com"},
+		})).
+		Attr("status", 200).
+		AllAttrs())
+}
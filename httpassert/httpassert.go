@@ -0,0 +1,153 @@
+/*
+Package httpassert ties log messages recorded by a
+[slogassert.Handler] to the individual HTTP requests that logged them,
+so that assertions can be made per-request even when many requests
+run concurrently against one server.
+
+	func TestServer(t *testing.T) {
+	     handler := slogassert.New(t, slog.LevelInfo, nil)
+	     requests := httpassert.New(t, handler, withLogger)
+	     server := httptest.NewServer(requests.Wrap(yourMux))
+	     defer server.Close()
+
+	     req, _ := requests.NewRequest("GET", server.URL+"/widgets", nil)
+	     _, _ = http.DefaultClient.Do(req)
+
+	     requests.AssertRequestLogged(req, slogassert.Msg("listing widgets"))
+	     requests.AssertEmpty(req)
+	}
+
+Here withLogger is whatever function your handlers use to put their
+request-scoped logger into the context; if they use [Logger] instead,
+it may be nil.
+*/
+package httpassert
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/thejerf/slogassert"
+)
+
+// Header is the HTTP header used to carry the request ID from the
+// test's request to the server.
+const Header = "X-Slogassert-Request"
+
+// RequestKey is the attribute key the request ID is logged under by
+// the per-request loggers.
+const RequestKey = "slogassert_request"
+
+type loggerKey struct{}
+
+// Logger returns the request-scoped logger the middleware put into
+// the context, or nil if there isn't one.
+func Logger(ctx context.Context) *slog.Logger {
+	logger, _ := ctx.Value(loggerKey{}).(*slog.Logger)
+	return logger
+}
+
+// Requests creates per-request loggers for an HTTP server and asserts
+// on what they log. Create one with [New].
+type Requests struct {
+	t       slogassert.Tester
+	handler *slogassert.Handler
+	inject  func(context.Context, *slog.Logger) context.Context
+	lastID  atomic.Uint64
+}
+
+// New returns a Requests that logs to the given handler. If inject is
+// not nil, it is called by the middleware to put the request-scoped
+// logger into the request's context, in whatever way the server's
+// handlers expect to find it. The logger is always available from
+// [Logger] as well.
+func New(
+	t slogassert.Tester,
+	handler *slogassert.Handler,
+	inject func(context.Context, *slog.Logger) context.Context,
+) *Requests {
+	return &Requests{t: t, handler: handler, inject: inject}
+}
+
+// Tag sets a new request ID in the request's Header, so that what the
+// server logs for it can be asserted on. It returns the request for
+// convenience.
+func (r *Requests) Tag(req *http.Request) *http.Request {
+	req.Header.Set(Header, strconv.FormatUint(r.lastID.Add(1), 10))
+	return req
+}
+
+// NewRequest is http.NewRequest, with the result passed through
+// [Requests.Tag].
+func (r *Requests) NewRequest(method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	return r.Tag(req), nil
+}
+
+// Wrap returns middleware that gives each request a logger on a child
+// of the Handler, created via WithAttrs with the request's ID under
+// [RequestKey]. Requests that were not tagged are given a new ID.
+func (r *Requests) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		id := req.Header.Get(Header)
+		if id == "" {
+			id = strconv.FormatUint(r.lastID.Add(1), 10)
+		}
+
+		logger := slog.New(r.handler.WithAttrs([]slog.Attr{
+			slog.String(RequestKey, id),
+		}))
+		ctx := context.WithValue(req.Context(), loggerKey{}, logger)
+		if r.inject != nil {
+			ctx = r.inject(ctx, logger)
+		}
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+// id returns the request ID the request was tagged with, failing the
+// test if it was not tagged.
+func (r *Requests) id(req *http.Request) string {
+	r.t.Helper()
+	id := req.Header.Get(Header)
+	if id == "" {
+		r.t.Fatalf("request for %s was not tagged; use Requests.Tag or Requests.NewRequest", req.URL)
+	}
+	return id
+}
+
+// AssertRequestLogged asserts the first log message logged while
+// serving the given request that matches the LogMessageMatch, as with
+// AssertPrecise. The request must have been tagged by
+// [Requests.Tag] or created by [Requests.NewRequest].
+func (r *Requests) AssertRequestLogged(req *http.Request, lmm slogassert.LogMessageMatch) {
+	r.t.Helper()
+	id := r.id(req)
+	r.handler.AssertPrecise(lmm.Attr(RequestKey, id))
+}
+
+// AssertEmpty asserts that all the log messages logged while serving
+// the given request have been asserted, ignoring those logged for any
+// other request.
+func (r *Requests) AssertEmpty(req *http.Request) {
+	r.t.Helper()
+	id := r.id(req)
+	count := 0
+	for _, lm := range r.handler.Unasserted() {
+		val, haveVal := lm.Attrs[RequestKey]
+		if haveVal && val.String() == id {
+			count++
+		}
+	}
+	if count > 0 {
+		r.handler.Fail("%d unasserted log message(s) for request %s; see printout above",
+			count, id)
+	}
+}
@@ -0,0 +1,141 @@
+package httpassert
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/thejerf/slogassert"
+)
+
+type recordingTester struct {
+	failures []string
+}
+
+func (rt *recordingTester) Helper() {}
+
+func (rt *recordingTester) Fatalf(msg string, args ...any) {
+	rt.failures = append(rt.failures, fmt.Sprintf(msg, args...))
+}
+
+// appLoggerKey is where the application under test expects its
+// request-scoped logger.
+type appLoggerKey struct{}
+
+func withLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, appLoggerKey{}, logger)
+}
+
+func widgets(w http.ResponseWriter, req *http.Request) {
+	logger := req.Context().Value(appLoggerKey{}).(*slog.Logger)
+	name := req.URL.Query().Get("name")
+	logger.Info("fetching widget", "name", name)
+	if name == "broken" {
+		logger.Error("widget is broken")
+	}
+	_, _ = w.Write([]byte(name))
+}
+
+func TestRequests(t *testing.T) {
+	handler := slogassert.New(t, slog.LevelInfo, nil)
+	defer handler.AssertEmpty()
+	requests := New(t, handler, withLogger)
+	server := httptest.NewServer(requests.Wrap(http.HandlerFunc(widgets)))
+	defer server.Close()
+
+	reqs := []*http.Request{}
+	for i := 0; i < 20; i++ {
+		req, err := requests.NewRequest("GET",
+			fmt.Sprintf("%s/?name=w%d", server.URL, i), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reqs = append(reqs, req)
+	}
+
+	wg := sync.WaitGroup{}
+	for _, req := range reqs {
+		wg.Add(1)
+		go func(req *http.Request) {
+			defer wg.Done()
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+		}(req)
+	}
+	wg.Wait()
+
+	for i, req := range reqs {
+		requests.AssertRequestLogged(req, slogassert.Msg("fetching widget").
+			Attr("name", fmt.Sprintf("w%d", i)).
+			AllAttrs())
+		requests.AssertEmpty(req)
+	}
+}
+
+func TestRequestFailures(t *testing.T) {
+	rt := &recordingTester{}
+	handler := slogassert.New(rt, slog.LevelInfo, nil)
+	requests := New(rt, handler, withLogger)
+	server := httptest.NewServer(requests.Wrap(http.HandlerFunc(widgets)))
+	defer server.Close()
+
+	broken, _ := requests.NewRequest("GET", server.URL+"/?name=broken", nil)
+	fine, _ := requests.NewRequest("GET", server.URL+"/?name=fine", nil)
+	for _, req := range []*http.Request{broken, fine} {
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	requests.AssertRequestLogged(fine, slogassert.Msg("fetching widget"))
+	requests.AssertEmpty(fine)
+	requests.AssertRequestLogged(broken, slogassert.Msg("fetching widget").
+		Attr("name", "fine"))
+	requests.AssertEmpty(broken)
+
+	untagged, _ := http.NewRequest("GET", server.URL, nil)
+	requests.AssertEmpty(untagged)
+
+	if len(rt.failures) != 3 ||
+		rt.failures[0] != "No logs matching filter were found" ||
+		rt.failures[1] != "2 unasserted log message(s) for request 1; see printout above" ||
+		!strings.HasSuffix(rt.failures[2], "was not tagged; use Requests.Tag or Requests.NewRequest") {
+		t.Fatalf("incorrect failures: %#v", rt.failures)
+	}
+}
+
+func TestLogger(t *testing.T) {
+	handler := slogassert.New(t, slog.LevelInfo, nil)
+	defer handler.AssertEmpty()
+	requests := New(t, handler, nil)
+
+	if Logger(context.Background()) != nil {
+		t.Fatal("got a logger from an empty context")
+	}
+
+	server := httptest.NewServer(requests.Wrap(http.HandlerFunc(
+		func(w http.ResponseWriter, req *http.Request) {
+			Logger(req.Context()).Info("hello")
+		},
+	)))
+	defer server.Close()
+
+	// untagged requests still get an ID
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	handler.AssertPrecise(slogassert.Msg("hello").Attr(RequestKey, "1"))
+}
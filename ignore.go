@@ -0,0 +1,84 @@
+package slogassert
+
+import (
+	"strings"
+)
+
+// An IgnoreRule reports on a LogMessageMatch installed with
+// [Handler.Ignore], and how many records it has swallowed.
+type IgnoreRule struct {
+	Match LogMessageMatch
+	Count int
+}
+
+type ignoreRule struct {
+	match LogMessageMatch
+	count int
+}
+
+// Ignore installs a persistent rule on the Handler that causes all
+// records matching the given LogMessageMatch to be ignored for the
+// life of the Handler. Ignored records are never stored, and so
+// never need to be asserted; they are still passed to any wrapped
+// handler.
+//
+// This is intended for messages the test can not control, such as
+// deprecation warnings from third-party libraries. Use
+// [Handler.IgnoreRules] or [Handler.AssertIgnoreRulesUsed] to find
+// rules that are no longer needed.
+//
+// Ignore rules are checked in the order they are installed, and a
+// record is counted against only the first rule it matches.
+func (h *Handler) Ignore(lmm LogMessageMatch) {
+	h.t.Helper()
+	h.validate(lmm)
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	root.ignores = append(root.ignores, &ignoreRule{match: lmm})
+}
+
+// IgnoreRules returns all the installed ignore rules, in the order
+// they were installed, with the number of records each has ignored.
+func (h *Handler) IgnoreRules() []IgnoreRule {
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	rules := []IgnoreRule{}
+	for _, rule := range root.ignores {
+		rules = append(rules, IgnoreRule{rule.match, rule.count})
+	}
+	return rules
+}
+
+// AssertIgnoreRulesUsed asserts that every installed ignore rule has
+// ignored at least one record. This can be used to find stale ignore
+// rules that can be deleted.
+func (h *Handler) AssertIgnoreRulesUsed() {
+	h.t.Helper()
+
+	unused := []string{}
+	for _, rule := range h.IgnoreRules() {
+		if rule.Count == 0 {
+			unused = append(unused, "  "+rule.Match.String())
+		}
+	}
+
+	if len(unused) > 0 {
+		h.Fail("%d ignore rule(s) never matched anything:\n%s",
+			len(unused), strings.Join(unused, "\n"))
+	}
+}
+
+// should be run only under handler lock
+func (h *Handler) ignored(lm LogMessage) bool {
+	for _, rule := range h.ignores {
+		if rule.match.Matches(lm) {
+			rule.count++
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package slogassert
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestIgnore(t *testing.T) {
+	wrapped := &strings.Builder{}
+	handler := New(t, slog.LevelWarn, slog.NewTextHandler(wrapped, nil))
+	defer handler.AssertEmpty()
+	log := slog.New(handler)
+
+	handler.Ignore(LogMessageMatch{
+		Message: "deprecated",
+		Level:   LevelDontCare,
+	})
+	handler.Ignore(LogMessageMatch{
+		Message: "never logged",
+		Level:   slog.LevelWarn,
+		Attrs:   map[string]any{"a": 1},
+	})
+
+	log.Warn("deprecated")
+	log.WithGroup("lib").Error("deprecated", "x", "y")
+	log.Warn(testWarning)
+
+	handler.AssertMessage(testWarning)
+	if strings.Count(wrapped.String(), "deprecated") != 2 {
+		t.Fatal("ignored records not passed to wrapped handler")
+	}
+
+	rules := handler.IgnoreRules()
+	if len(rules) != 2 || rules[0].Count != 2 || rules[1].Count != 0 {
+		t.Fatalf("incorrect ignore rules: %#v", rules)
+	}
+
+	rt := &recordingTester{}
+	handler.t = rt
+	handler.AssertIgnoreRulesUsed()
+	handler.t = t
+	if len(rt.failures) != 1 ||
+		!strings.Contains(rt.failures[0],
+			`message "never logged" level WARN attrs {a: 1}`) {
+		t.Fatalf("incorrect unused ignore rule failure: %v", rt.failures)
+	}
+}
+
+func TestDefaultIgnore(t *testing.T) {
+	handler := NewDefault(t,
+		WithAssertEmpty(),
+		WithIgnore(LogMessageMatch{Message: "noise", Level: LevelDontCare}),
+	)
+	slog.Info("noise")
+	handler.AssertIgnoreRulesUsed()
+}
+
+func TestLogMessageMatchString(t *testing.T) {
+	lmm := LogMessageMatch{
+		Message:       "msg",
+		Level:         LevelDontCare,
+		Attrs:         map[string]any{"b": "x", "a": true},
+		AllAttrsMatch: true,
+	}
+	if lmm.String() != `message "msg" attrs {a: true, b: "x"} (all attrs)` {
+		t.Fatalf("incorrect String: %s", lmm.String())
+	}
+}
@@ -0,0 +1,81 @@
+package slogassert
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+)
+
+// Key returns the key for an attribute in the given groups, as used
+// in LogMessageMatch.Attrs and LogMessage.Attrs. The last argument is
+// the attribute's own key; any before it are the groups it is in,
+// outermost first. Dots and backslashes are escaped as necessary, so
+//
+//	slogassert.Key("payment", "amount")
+//
+// is "payment.amount", and Key("a.b") is "a\.b".
+func Key(groups ...string) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	return encgroups(groups[:len(groups)-1], groups[len(groups)-1])
+}
+
+// SplitKey is the inverse of [Key], splitting a key as used in
+// LogMessage.Attrs into the groups and the attribute's own key, with
+// any escaping undone. The attribute's key is the last element of
+// the result.
+func SplitKey(key string) []string {
+	groups, attrKey := decgroups(key)
+	return append(groups, attrKey)
+}
+
+var slogValueType = reflect.TypeOf(slog.Value{})
+
+// Get returns the value of the attribute with the given key in the
+// log message, as a T. The key is as used in LogMessage.Attrs; see
+// [Key].
+//
+// The slog.Value is unwrapped according to its Kind, so T should be
+// int64 for a KindInt64, uint64 for a KindUint64, time.Duration for a
+// KindDuration, and so on, and the concrete type of the value for a
+// KindAny. LogValuers are resolved. T may also be slog.Value, to get
+// the value itself; any other interface type, such as any or
+// fmt.Stringer, gets the unwrapped value.
+//
+// If the attribute is not present, or is not a T, the zero value and
+// false are returned.
+func Get[T any](lm LogMessage, key string) (T, bool) {
+	var zero T
+	val, present := lm.Attrs[key]
+	if !present {
+		return zero, false
+	}
+
+	// only return the slog.Value itself if it was asked for; any
+	// interface would also accept it
+	if reflect.TypeOf((*T)(nil)).Elem() == slogValueType {
+		return any(val).(T), true
+	}
+	v, isT := val.Resolve().Any().(T)
+	if !isT {
+		return zero, false
+	}
+	return v, true
+}
+
+// MustGet is like [Get], but panics if the attribute is not present
+// or is not a T.
+func MustGet[T any](lm LogMessage, key string) T {
+	v, ok := Get[T](lm, key)
+	if !ok {
+		val, present := lm.Attrs[key]
+		if !present {
+			panic(fmt.Sprintf("slogassert: no attribute %q in log message %q",
+				key, lm.Message))
+		}
+		panic(fmt.Sprintf("slogassert: attribute %q of kind %s is not a %s",
+			key, val.Kind(), reflect.TypeOf((*T)(nil)).Elem()))
+	}
+	return v
+}
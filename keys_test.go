@@ -0,0 +1,91 @@
+package slogassert
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestKey(t *testing.T) {
+	for _, test := range []struct {
+		groups []string
+		key    string
+	}{
+		{nil, ""},
+		{[]string{"url"}, "url"},
+		{[]string{"webserver", "request", "url"}, "webserver.request.url"},
+		{[]string{"a.b"}, "a\\.b"},
+		{[]string{"a\\", "b"}, "a\\\\.b"},
+	} {
+		if Key(test.groups...) != test.key {
+			t.Fatalf("incorrect key for %v: %q", test.groups, Key(test.groups...))
+		}
+	}
+}
+
+func TestSplitKey(t *testing.T) {
+	for _, parts := range [][]string{
+		{""},
+		{"url"},
+		{"webserver", "request", "url"},
+		{"a.b", "c\\", "d"},
+	} {
+		if !reflect.DeepEqual(SplitKey(Key(parts...)), parts) {
+			t.Fatalf("incorrect round trip for %v: %v", parts,
+				SplitKey(Key(parts...)))
+		}
+	}
+}
+
+func TestGet(t *testing.T) {
+	err := errors.New("oops")
+	lm := LogMessage{
+		Message: testWarning,
+		Attrs: map[string]slog.Value{
+			"int":      slog.Int64Value(1),
+			"duration": slog.DurationValue(time.Second),
+			"err":      slog.AnyValue(err),
+			"valuer":   slog.AnyValue(ValueAsString(5)),
+		},
+	}
+
+	if i, ok := Get[int64](lm, "int"); !ok || i != 1 {
+		t.Fatal("could not get int64")
+	}
+	if _, ok := Get[int](lm, "int"); ok {
+		t.Fatal("got an int64 as an int")
+	}
+	if d, ok := Get[time.Duration](lm, "duration"); !ok || d != time.Second {
+		t.Fatal("could not get duration")
+	}
+	if e, ok := Get[error](lm, "err"); !ok || e != err {
+		t.Fatal("could not get error")
+	}
+	if s, ok := Get[string](lm, "valuer"); !ok || s != "5" {
+		t.Fatal("did not resolve LogValuer")
+	}
+	if v, ok := Get[slog.Value](lm, "int"); !ok || v.Int64() != 1 {
+		t.Fatal("could not get slog.Value")
+	}
+	if a, ok := Get[any](lm, "int"); !ok || a != int64(1) {
+		t.Fatalf("Get[any] did not unwrap the value: %#v", a)
+	}
+	if s, ok := Get[fmt.Stringer](lm, "duration"); !ok || s != time.Second {
+		t.Fatalf("Get[fmt.Stringer] did not unwrap the value: %#v", s)
+	}
+	if _, ok := Get[fmt.Stringer](lm, "int"); ok {
+		t.Fatal("got an int64 as a fmt.Stringer")
+	}
+	if _, ok := Get[int64](lm, "missing"); ok {
+		t.Fatal("got a missing attribute")
+	}
+
+	if MustGet[int64](lm, "int") != 1 {
+		t.Fatal("could not MustGet int64")
+	}
+	panics(t, "MustGet missing", func() { MustGet[int64](lm, "missing") })
+	panics(t, "MustGet wrong type", func() { MustGet[error](lm, "int") })
+}
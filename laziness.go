@@ -0,0 +1,100 @@
+package slogassert
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+// An EnabledCall records a call to the Handler's Enabled method, with
+// the level it was called with and the result it returned.
+type EnabledCall struct {
+	Level   slog.Level
+	Enabled bool
+}
+
+type resolution struct {
+	key   string
+	level slog.Level
+}
+
+// EnabledCalls returns how many times Enabled has been called on the
+// root Handler or any Handler derived from it, by level and result.
+func (h *Handler) EnabledCalls() map[EnabledCall]int {
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	calls := map[EnabledCall]int{}
+	for call, count := range root.enabledCalls {
+		calls[call] = count
+	}
+	return calls
+}
+
+// AssertEnabledCalled asserts that Enabled was called exactly the
+// given number of times with the given level, regardless of the
+// result it returned.
+//
+// This can be used to prove that code checks logger.Enabled before
+// doing expensive work to build log messages.
+func (h *Handler) AssertEnabledCalled(level slog.Level, times int) {
+	h.t.Helper()
+
+	count := 0
+	for call, calls := range h.EnabledCalls() {
+		if call.Level == level {
+			count += calls
+		}
+	}
+
+	if count != times {
+		h.Fail("Enabled called %d time(s) for level %s, expected %d",
+			count, level, times)
+	}
+}
+
+// Resolutions returns the number of times a slog.LogValuer was
+// resolved while handling records, keyed by the attribute key the
+// same way as LogMessage.Attrs.
+//
+// LogValuers in attributes added by WithAttrs are resolved on every
+// record handled, and will be counted each time.
+func (h *Handler) Resolutions() map[string]int {
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	resolutions := map[string]int{}
+	for res, count := range root.resolutions {
+		resolutions[res.key] += count
+	}
+	return resolutions
+}
+
+// AssertNoResolutionBelow asserts that no slog.LogValuer was resolved
+// for a record with a level below the given level.
+//
+// Combined with a Handler that captures at a lower level, this can
+// show that expensive values are only computed for the levels they
+// are meant to be computed at.
+func (h *Handler) AssertNoResolutionBelow(level slog.Level) {
+	h.t.Helper()
+	root := h.root()
+	root.m.Lock()
+	below := []string{}
+	for res, count := range root.resolutions {
+		if res.level < level {
+			below = append(below, fmt.Sprintf("  %s at level %s: %d time(s)",
+				res.key, res.level, count))
+		}
+	}
+	root.m.Unlock()
+
+	if len(below) > 0 {
+		sort.Strings(below)
+		h.Fail("LogValuers resolved below level %s:\n%s",
+			level, strings.Join(below, "\n"))
+	}
+}
@@ -0,0 +1,81 @@
+package slogassert
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+type countingValuer struct {
+	count *int
+}
+
+func (cv countingValuer) LogValue() slog.Value {
+	*cv.count++
+	return slog.StringValue("expensive")
+}
+
+func TestEnabledCalls(t *testing.T) {
+	handler := New(t, slog.LevelInfo, nil)
+	defer handler.AssertEmpty()
+	log := slog.New(handler)
+
+	log.Debug("disabled")
+	log.Debug("disabled")
+	log.Info("enabled")
+	if log.WithGroup("g").Enabled(context.Background(), slog.LevelWarn) != true {
+		t.Fatal("incorrect Enabled result")
+	}
+	handler.AssertMessage("enabled")
+
+	calls := handler.EnabledCalls()
+	if calls[EnabledCall{slog.LevelDebug, false}] != 2 ||
+		calls[EnabledCall{slog.LevelInfo, true}] != 1 ||
+		calls[EnabledCall{slog.LevelWarn, true}] != 1 {
+		t.Fatalf("incorrect Enabled calls: %v", calls)
+	}
+
+	handler.AssertEnabledCalled(slog.LevelDebug, 2)
+	handler.AssertEnabledCalled(slog.LevelError, 0)
+
+	rt := &recordingTester{}
+	failing := New(rt, slog.LevelInfo, nil)
+	failing.AssertEnabledCalled(slog.LevelInfo, 1)
+	if len(rt.failures) != 1 {
+		t.Fatal("AssertEnabledCalled did not fail")
+	}
+}
+
+func TestResolutions(t *testing.T) {
+	handler := New(t, slog.LevelDebug, nil)
+	defer handler.AssertEmpty()
+	count := 0
+	log := slog.New(handler).With("withattr", countingValuer{&count})
+
+	log.Info("info", "direct", countingValuer{&count},
+		slog.Group("g", "grouped", countingValuer{&count}))
+	log.Warn("warn")
+	handler.AssertMessage("info")
+	handler.AssertMessage("warn")
+
+	if count != 4 {
+		t.Fatalf("incorrect number of resolutions: %d", count)
+	}
+	resolutions := handler.Resolutions()
+	if resolutions["withattr"] != 2 ||
+		resolutions["direct"] != 1 ||
+		resolutions["g.grouped"] != 1 ||
+		len(resolutions) != 3 {
+		t.Fatalf("incorrect resolutions: %v", resolutions)
+	}
+
+	handler.AssertNoResolutionBelow(slog.LevelInfo)
+
+	rt := &recordingTester{}
+	handler.t = rt
+	handler.AssertNoResolutionBelow(slog.LevelWarn)
+	handler.t = t
+	if len(rt.failures) != 1 {
+		t.Fatal("AssertNoResolutionBelow did not fail")
+	}
+}
@@ -0,0 +1,117 @@
+package slogassert
+
+import (
+	"context"
+	"log/slog"
+	"testing/slogtest"
+	"time"
+)
+
+// TestMiddleware tests that a slog.Handler middleware, that is, a
+// slog.Handler that wraps another slog.Handler, correctly passes
+// records down to the handler it wraps.
+//
+// The middleware function will be passed a slogassert *Handler as the
+// next handler, and must return the middleware wrapping it. It may be
+// called more than once.
+//
+// This runs the middleware through the testing/slogtest checks, which
+// exercise WithAttrs, WithGroup, and attribute resolution, and then
+// through checks that the middleware preserves the level and message
+// of records, and delegates Enabled to the next handler.
+//
+// Middleware that deliberately drops or rewrites records, such as a
+// sampler or a redactor, should be configured by the passed function
+// to pass through the records used by the tests.
+//
+// Failures are reported through t.Fatalf.
+func TestMiddleware(t Tester, middleware func(next slog.Handler) slog.Handler) {
+	t.Helper()
+
+	next := New(t, slog.LevelDebug, nil)
+	err := slogtest.TestHandler(middleware(next), slogtestResults(next))
+	if err != nil {
+		t.Fatalf("middleware does not correctly wrap handlers: %v", err)
+		return
+	}
+
+	ctx := context.Background()
+	levels := []slog.Level{
+		slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError,
+		slog.LevelWarn + 2,
+	}
+
+	logger := slog.New(middleware(next))
+	for _, level := range levels {
+		logger.Log(ctx, level, "level test", "level", level.String())
+		found := next.Assert(trueOnlyOnce(func(lm LogMessage) bool {
+			return lm.Message == "level test" && lm.Level == level
+		}))
+		next.Reset()
+		if found == 0 {
+			t.Fatalf("middleware did not preserve record at level %s", level)
+			return
+		}
+	}
+
+	for _, level := range levels {
+		leveler := &slog.LevelVar{}
+		leveler.Set(level)
+		restricted := middleware(New(t, leveler, nil))
+
+		if !restricted.Enabled(ctx, level) {
+			t.Fatalf("middleware not enabled for level %s when next is", level)
+			return
+		}
+		if restricted.Enabled(ctx, level-1) {
+			t.Fatalf("middleware enabled for level %s when next is not", level-1)
+			return
+		}
+	}
+}
+
+// slogtestResults returns a function suitable for use as the results
+// function for slogtest.TestHandler, which empties the handler of its
+// log messages.
+func slogtestResults(h *Handler) func() []map[string]any {
+	return func() []map[string]any {
+		results := []map[string]any{}
+		root := h.root()
+		root.m.Lock()
+		defer root.m.Unlock()
+
+		for _, lm := range root.logMessages {
+			results = append(results, lm.slogtestMap())
+		}
+		root.logMessages = nil
+		return results
+	}
+}
+
+// slogtestMap converts the LogMessage into the nested map format
+// expected by testing/slogtest.
+func (lm *LogMessage) slogtestMap() map[string]any {
+	result := map[string]any{
+		slog.LevelKey:   lm.Level,
+		slog.MessageKey: lm.Message,
+	}
+	if !lm.Time.Equal(time.Time{}) {
+		result[slog.TimeKey] = lm.Time
+	}
+
+	for encKey, val := range lm.Attrs {
+		groups, key := decgroups(encKey)
+		target := result
+		for _, group := range groups {
+			sub, isMap := target[group].(map[string]any)
+			if !isMap {
+				sub = map[string]any{}
+				target[group] = sub
+			}
+			target = sub
+		}
+		target[key] = val.Any()
+	}
+
+	return result
+}
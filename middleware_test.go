@@ -0,0 +1,102 @@
+package slogassert
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+// recordingTester is a Tester that records failures rather than
+// stopping the test, for testing code that is supposed to fail.
+type recordingTester struct {
+	failures []string
+}
+
+func (rt *recordingTester) Helper() {}
+
+func (rt *recordingTester) Fatalf(msg string, args ...any) {
+	rt.failures = append(rt.failures, fmt.Sprintf(msg, args...))
+}
+
+// passthrough is a correct middleware that adds nothing.
+type passthrough struct {
+	next slog.Handler
+}
+
+func (p passthrough) Enabled(ctx context.Context, level slog.Level) bool {
+	return p.next.Enabled(ctx, level)
+}
+
+func (p passthrough) Handle(ctx context.Context, record slog.Record) error {
+	return p.next.Handle(ctx, record)
+}
+
+func (p passthrough) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return passthrough{p.next.WithAttrs(attrs)}
+}
+
+func (p passthrough) WithGroup(name string) slog.Handler {
+	return passthrough{p.next.WithGroup(name)}
+}
+
+// forgetsGroups is a broken middleware that doesn't pass WithGroup
+// down.
+type forgetsGroups struct {
+	passthrough
+}
+
+func (fg forgetsGroups) WithGroup(_ string) slog.Handler {
+	return fg
+}
+
+// alwaysEnabled is a broken middleware that doesn't delegate Enabled.
+type alwaysEnabled struct {
+	passthrough
+}
+
+func (ae alwaysEnabled) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func TestTestMiddleware(t *testing.T) {
+	TestMiddleware(t, func(next slog.Handler) slog.Handler {
+		return passthrough{next}
+	})
+
+	rt := &recordingTester{}
+	TestMiddleware(rt, func(next slog.Handler) slog.Handler {
+		return forgetsGroups{passthrough{next}}
+	})
+	if len(rt.failures) == 0 {
+		t.Fatal("middleware dropping groups not detected")
+	}
+
+	rt = &recordingTester{}
+	TestMiddleware(rt, func(next slog.Handler) slog.Handler {
+		return alwaysEnabled{passthrough{next}}
+	})
+	if len(rt.failures) != 1 {
+		t.Fatalf("middleware not delegating Enabled not detected: %v",
+			rt.failures)
+	}
+}
+
+func TestDecgroups(t *testing.T) {
+	for _, test := range []struct {
+		groups []string
+		key    string
+	}{
+		{[]string{}, "a"},
+		{[]string{}, ""},
+		{[]string{"a.b", "c\\"}, "d.\\."},
+		{[]string{"", "x"}, "y"},
+	} {
+		groups, key := decgroups(encgroups(test.groups, test.key))
+		if !reflect.DeepEqual(groups, test.groups) || key != test.key {
+			t.Fatalf("incorrect round trip for %#v: %#v %q",
+				test, groups, key)
+		}
+	}
+}
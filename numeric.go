@@ -0,0 +1,137 @@
+package slogassert
+
+import (
+	"log/slog"
+	"math"
+)
+
+// numericKind classifies a Go numeric literal used as a matcher.
+type numericKind int
+
+const (
+	numericSigned numericKind = iota
+	numericUnsigned
+	numericFloat
+)
+
+// number holds a Go numeric literal in the widest type of its class,
+// so it can be compared against any numeric slog.Kind without loss.
+type number struct {
+	kind numericKind
+	i    int64
+	u    uint64
+	f    float64
+}
+
+// asNumber widens any Go integer or float value into a number. The
+// second return is false if the matcher is not a plain numeric type.
+func asNumber(matcher any) (number, bool) {
+	switch m := matcher.(type) {
+	case int:
+		return number{kind: numericSigned, i: int64(m)}, true
+	case int8:
+		return number{kind: numericSigned, i: int64(m)}, true
+	case int16:
+		return number{kind: numericSigned, i: int64(m)}, true
+	case int32:
+		return number{kind: numericSigned, i: int64(m)}, true
+	case int64:
+		return number{kind: numericSigned, i: m}, true
+	case uint:
+		return number{kind: numericUnsigned, u: uint64(m)}, true
+	case uint8:
+		return number{kind: numericUnsigned, u: uint64(m)}, true
+	case uint16:
+		return number{kind: numericUnsigned, u: uint64(m)}, true
+	case uint32:
+		return number{kind: numericUnsigned, u: uint64(m)}, true
+	case uint64:
+		return number{kind: numericUnsigned, u: m}, true
+	case uintptr:
+		return number{kind: numericUnsigned, u: uint64(m)}, true
+	case float32:
+		// This is the same widening slog.AnyValue does, so a
+		// float32 matches the same float32 logged.
+		return number{kind: numericFloat, f: float64(m)}, true
+	case float64:
+		return number{kind: numericFloat, f: m}, true
+	default:
+		return number{}, false
+	}
+}
+
+// matchNumeric compares a Go numeric matcher against a numeric
+// slog.Value by mathematical value. applicable is false if val is not
+// of a numeric kind, in which case the caller should fall back to its
+// usual type checking.
+//
+// Comparisons never wrap or round: a negative number never equals a
+// KindUint64, and a float only equals an integer if it is integral and
+// exactly equal to it.
+func matchNumeric(n number, val slog.Value) (equal, applicable bool) {
+	switch val.Kind() {
+	case slog.KindInt64:
+		return n.equalsInt(val.Int64()), true
+	case slog.KindUint64:
+		return n.equalsUint(val.Uint64()), true
+	case slog.KindFloat64:
+		return n.equalsFloat(val.Float64()), true
+	default:
+		return false, false
+	}
+}
+
+func (n number) equalsInt(v int64) bool {
+	switch n.kind {
+	case numericSigned:
+		return n.i == v
+	case numericUnsigned:
+		return v >= 0 && uint64(v) == n.u
+	default:
+		i, exact := floatToInt(n.f)
+		return exact && i == v
+	}
+}
+
+func (n number) equalsUint(v uint64) bool {
+	switch n.kind {
+	case numericSigned:
+		return n.i >= 0 && uint64(n.i) == v
+	case numericUnsigned:
+		return n.u == v
+	default:
+		u, exact := floatToUint(n.f)
+		return exact && u == v
+	}
+}
+
+func (n number) equalsFloat(v float64) bool {
+	switch n.kind {
+	case numericSigned:
+		i, exact := floatToInt(v)
+		return exact && i == n.i
+	case numericUnsigned:
+		u, exact := floatToUint(v)
+		return exact && u == n.u
+	default:
+		return n.f == v
+	}
+}
+
+// floatToInt converts f to an int64 if that can be done exactly.
+func floatToInt(f float64) (int64, bool) {
+	// -2^63 is exactly representable as a float64, 2^63 is the
+	// first value above the int64 range.
+	if f != math.Trunc(f) || f < -(1<<63) || f >= 1<<63 {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// floatToUint converts f to a uint64 if that can be done exactly.
+func floatToUint(f float64) (uint64, bool) {
+	if f != math.Trunc(f) || f < 0 || f >= 1<<64 {
+		return 0, false
+	}
+	return uint64(f), true
+}
@@ -0,0 +1,109 @@
+package slogassert
+
+import (
+	"log/slog"
+	"math"
+	"testing"
+)
+
+func TestNumericMatching(t *testing.T) {
+	for _, test := range []struct {
+		matcher any
+		value   slog.Value
+		matches bool
+	}{
+		{int32(5), slog.Int64Value(5), true},
+		{uint(5), slog.Int64Value(5), true},
+		{int64(5), slog.Uint64Value(5), true},
+		{int8(5), slog.Uint64Value(5), true},
+		{int64(5), slog.Float64Value(5), true},
+		{uint16(5), slog.Float64Value(5), true},
+		{5.0, slog.Int64Value(5), true},
+		{float32(5), slog.Uint64Value(5), true},
+		{float32(0.1), slog.AnyValue(float32(0.1)), true},
+		{uintptr(7), slog.Uint64Value(7), true},
+		{int64(math.MaxInt64), slog.Uint64Value(math.MaxInt64), true},
+		{uint64(math.MaxUint64), slog.Uint64Value(math.MaxUint64), true},
+		{float64(-(1 << 63)), slog.Int64Value(math.MinInt64), true},
+
+		{int32(6), slog.Int64Value(5), false},
+		{-1, slog.Uint64Value(math.MaxUint64), false},
+		{uint64(math.MaxUint64), slog.Int64Value(-1), false},
+		{5.5, slog.Int64Value(5), false},
+		{5, slog.Float64Value(5.5), false},
+		{float64(1 << 63), slog.Int64Value(math.MaxInt64), false},
+		{-1.0, slog.Uint64Value(math.MaxUint64), false},
+		{float64(1 << 64), slog.Uint64Value(math.MaxUint64), false},
+		// 2^53+1 can't be a float64, so the nearest float must
+		// not be considered equal to it.
+		{int64(1<<53 + 1), slog.Float64Value(1 << 53), false},
+		{math.NaN(), slog.Float64Value(math.NaN()), false},
+		{math.Inf(1), slog.Int64Value(math.MaxInt64), false},
+	} {
+		err := matchAttr(test.matcher, test.value)
+		if test.matches && err != nil {
+			t.Fatalf("%T(%v) should match %v: %v", test.matcher,
+				test.matcher, test.value, err)
+		}
+		if !test.matches && err != errNoMatch {
+			t.Fatalf("%T(%v) should not match %v: %v", test.matcher,
+				test.matcher, test.value, err)
+		}
+	}
+
+	// numeric matchers still can't match non-numeric kinds
+	if matchAttr(5, slog.StringValue("5")) == nil ||
+		matchAttr(5, slog.StringValue("5")) == errNoMatch {
+		t.Fatal("numeric matcher did not give a type error on a string")
+	}
+}
+
+func TestStrictKinds(t *testing.T) {
+	for _, test := range []struct {
+		matcher any
+		value   slog.Value
+	}{
+		{int64(5), slog.Int64Value(5)},
+		{uint64(5), slog.Uint64Value(5)},
+		{float64(5), slog.Float64Value(5)},
+	} {
+		if matchAttrKinds(test.matcher, test.value, true) != nil {
+			t.Fatalf("%T should strictly match %v", test.matcher, test.value)
+		}
+	}
+
+	for _, test := range []struct {
+		matcher any
+		value   slog.Value
+	}{
+		{5, slog.Int64Value(5)},
+		{int32(5), slog.Int64Value(5)},
+		{int64(5), slog.Uint64Value(5)},
+		{5, slog.Float64Value(5)},
+	} {
+		err := matchAttrKinds(test.matcher, test.value, true)
+		if err == nil || err == errNoMatch {
+			t.Fatalf("%T should be a type error against %v, got %v",
+				test.matcher, test.value, err)
+		}
+	}
+
+	lm := LogMessage{
+		Message: "count",
+		Level:   slog.LevelInfo,
+		Attrs:   map[string]slog.Value{"n": slog.Uint64Value(5)},
+	}
+	lmm := Msg("count").Attr("n", 5)
+	if !lmm.Matches(lm) {
+		t.Fatal("int does not match a uint64 value")
+	}
+	if lmm.Strict().Matches(lm) {
+		t.Fatal("int matches a uint64 value with strict kinds")
+	}
+	if !lmm.Attr("n", uint64(5)).Strict().Matches(lm) {
+		t.Fatal("uint64 does not match a uint64 value with strict kinds")
+	}
+	if lmm.Strict().String() != `message "count" attrs {n: 5} (strict kinds)` {
+		t.Fatalf("incorrect strict description: %s", lmm.Strict())
+	}
+}
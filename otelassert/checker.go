@@ -0,0 +1,117 @@
+package otelassert
+
+import (
+	"fmt"
+
+	"github.com/thejerf/slogassert"
+)
+
+// A Checker asserts on the spans records were logged in. Create one
+// with [New].
+//
+// TraceIDKey and SpanIDKey are the attribute keys, as used in
+// LogMessage.Attrs, that records are expected to carry the trace and
+// span IDs in, as hex strings. They default to "trace_id" and
+// "span_id". Setting one to "" disables checking it.
+type Checker struct {
+	t       slogassert.Tester
+	handler *slogassert.Handler
+
+	TraceIDKey string
+	SpanIDKey  string
+}
+
+// New registers [Extract] on the handler under [SpanKey] and returns
+// a Checker for it. Only records handled after this call have their
+// span recorded.
+func New(t slogassert.Tester, handler *slogassert.Handler) *Checker {
+	handler.ExtractContext(SpanKey, Extract)
+	return &Checker{
+		t:          t,
+		handler:    handler,
+		TraceIDKey: "trace_id",
+		SpanIDKey:  "span_id",
+	}
+}
+
+// AssertLoggedInSpan asserts all the unasserted records logged in a
+// span with the given name, and returns how many there were. If there
+// were none, the test fails.
+func (c *Checker) AssertLoggedInSpan(name string) int {
+	c.t.Helper()
+	matches := c.handler.Assert(func(lm slogassert.LogMessage) bool {
+		span, inSpan := spanOf(lm)
+		return inSpan && span.Name == name
+	})
+	if matches == 0 {
+		c.handler.Fail("No logs in span %q found", name)
+	}
+	return matches
+}
+
+// AssertTraceConsistent checks all the unasserted records, failing
+// the test on the first record that:
+//
+//   - was logged in a span that had already ended,
+//   - was logged in a span but does not carry that span's trace and
+//     span IDs in its attributes, or
+//   - carries trace or span ID attributes without being logged in a
+//     span at all.
+//
+// This does not assert the records.
+func (c *Checker) AssertTraceConsistent() {
+	c.t.Helper()
+	for _, lm := range c.handler.Unasserted() {
+		err := c.consistent(lm)
+		if err != nil {
+			c.handler.Fail("log message %q %s", lm.Message, err)
+			return
+		}
+	}
+}
+
+func (c *Checker) consistent(lm slogassert.LogMessage) error {
+	span, inSpan := spanOf(lm)
+	if !inSpan {
+		for _, key := range []string{c.TraceIDKey, c.SpanIDKey} {
+			if _, haveAttr := lm.Attrs[key]; key != "" && haveAttr {
+				return fmt.Errorf("has %s but was not logged in a span", key)
+			}
+		}
+		return nil
+	}
+
+	if span.Ended {
+		return fmt.Errorf("was logged in span %s after it ended",
+			describe(span))
+	}
+
+	for _, check := range []struct {
+		key  string
+		want string
+	}{
+		{c.TraceIDKey, span.SpanContext.TraceID().String()},
+		{c.SpanIDKey, span.SpanContext.SpanID().String()},
+	} {
+		if check.key == "" {
+			continue
+		}
+		val, haveAttr := lm.Attrs[check.key]
+		if !haveAttr {
+			return fmt.Errorf("was logged in span %s but has no %s",
+				describe(span), check.key)
+		}
+		if val.String() != check.want {
+			return fmt.Errorf("was logged in span %s but has %s %s, not %s",
+				describe(span), check.key, val, check.want)
+		}
+	}
+	return nil
+}
+
+func describe(span Span) string {
+	if span.Name == "" {
+		return span.SpanContext.SpanID().String()
+	}
+	return fmt.Sprintf("%q (%s)", span.Name, span.SpanContext.SpanID())
+}
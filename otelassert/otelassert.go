@@ -0,0 +1,94 @@
+/*
+Package otelassert checks that slog records are correctly correlated
+with OpenTelemetry traces.
+
+It records the span active in the context passed to the
+[slogassert.Handler]'s Handle method on each LogMessage, then asserts
+on the result:
+
+	func TestCheckout(t *testing.T) {
+	     handler := slogassert.New(t, slog.LevelInfo, nil)
+	     spans := otelassert.New(t, handler)
+	     logger := slog.New(yourOTelBridge(handler))
+
+	     // run code that logs with logger.InfoContext(ctx, ...)
+
+	     // every record carries the trace_id and span_id of the
+	     // span it was logged in, and none were logged in an
+	     // already-ended span
+	     spans.AssertTraceConsistent()
+	     spans.AssertLoggedInSpan("checkout")
+	     handler.AssertEmpty()
+	}
+
+This is a separate module so that slogassert itself does not depend
+on OpenTelemetry.
+*/
+package otelassert
+
+import (
+	"context"
+	"time"
+
+	"github.com/thejerf/slogassert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SpanKey is the name of the context extractor otelassert registers,
+// under which the Span is stored in a LogMessage's Context. It can be
+// used to match on the span with LogMessageMatch.Context or
+// LogMessageMatch.InContext, usually with [InSpan].
+const SpanKey = "otel.span"
+
+// A Span describes the span that was active when a record was
+// handled.
+//
+// Name is only available for spans that expose it, as the OTel SDK's
+// spans do; otherwise it is empty. Ended is true if the span had
+// already been ended when the record was handled, which is only
+// detectable for spans that expose their end time, again as the SDK's
+// spans do.
+type Span struct {
+	SpanContext trace.SpanContext
+	Name        string
+	Ended       bool
+}
+
+// Extract is a slogassert.ContextExtractor that extracts the active
+// span from the context as a Span. It is registered by [New] under
+// [SpanKey], but may be registered directly with
+// slogassert.WithContextExtractor.
+func Extract(ctx context.Context) (any, bool) {
+	span := trace.SpanFromContext(ctx)
+	sc := span.SpanContext()
+	if !sc.IsValid() {
+		return nil, false
+	}
+
+	s := Span{SpanContext: sc}
+	named, isNamed := span.(interface{ Name() string })
+	if isNamed {
+		s.Name = named.Name()
+	}
+	ender, isEnder := span.(interface{ EndTime() time.Time })
+	if isEnder {
+		s.Ended = !ender.EndTime().IsZero()
+	}
+	return s, true
+}
+
+// InSpan returns a matcher for use in LogMessageMatch.Context under
+// [SpanKey] that matches records logged in a span with the given
+// name.
+func InSpan(name string) func(any) bool {
+	return func(v any) bool {
+		span, isSpan := v.(Span)
+		return isSpan && span.Name == name
+	}
+}
+
+// spanOf returns the Span a LogMessage was logged in.
+func spanOf(lm slogassert.LogMessage) (Span, bool) {
+	span, isSpan := lm.Context[SpanKey].(Span)
+	return span, isSpan
+}
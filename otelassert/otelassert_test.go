@@ -0,0 +1,168 @@
+package otelassert
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/thejerf/slogassert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceAttrs is a minimal version of the sort of slog/OTel bridge
+// this package is meant to test; it adds the IDs of the active span.
+type traceAttrs struct {
+	slog.Handler
+}
+
+func (ta traceAttrs) Handle(ctx context.Context, record slog.Record) error {
+	sc := trace.SpanContextFromContext(ctx)
+	if sc.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+	return ta.Handler.Handle(ctx, record)
+}
+
+type recordingTester struct {
+	failures []string
+}
+
+func (rt *recordingTester) Helper() {}
+
+func (rt *recordingTester) Fatalf(msg string, args ...any) {
+	rt.failures = append(rt.failures, fmt.Sprintf(msg, args...))
+}
+
+func newTracer() (trace.Tracer, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	return provider.Tracer("otelassert"), exporter
+}
+
+func TestSpans(t *testing.T) {
+	tracer, exporter := newTracer()
+	handler := slogassert.New(t, slog.LevelInfo, nil)
+	defer handler.AssertEmpty()
+	spans := New(t, handler)
+	logger := slog.New(traceAttrs{handler})
+
+	ctx, checkout := tracer.Start(context.Background(), "checkout")
+	logger.InfoContext(ctx, "started")
+	payCtx, pay := tracer.Start(ctx, "pay")
+	logger.InfoContext(payCtx, "charged")
+	pay.End()
+	logger.InfoContext(ctx, "done")
+	checkout.End()
+	logger.Info("outside")
+
+	if len(exporter.GetSpans()) != 2 {
+		t.Fatalf("incorrect spans exported: %v", exporter.GetSpans())
+	}
+
+	msgs := handler.Unasserted()
+	span, isSpan := msgs[1].Context[SpanKey].(Span)
+	if !isSpan || span.Name != "pay" || span.Ended ||
+		span.SpanContext.SpanID() != pay.SpanContext().SpanID() ||
+		span.SpanContext.TraceID() != checkout.SpanContext().TraceID() {
+		t.Fatalf("incorrect span recorded: %#v", msgs[1].Context)
+	}
+	if _, inSpan := msgs[3].Context[SpanKey]; inSpan {
+		t.Fatal("span recorded outside of any span")
+	}
+
+	spans.AssertTraceConsistent()
+	handler.AssertPrecise(slogassert.Msg("charged").
+		InContext(SpanKey, InSpan("pay")))
+	if spans.AssertLoggedInSpan("checkout") != 2 {
+		t.Fatal("incorrect number of records in checkout span")
+	}
+	handler.AssertMessage("outside")
+}
+
+func TestTraceInconsistencies(t *testing.T) {
+	tracer, _ := newTracer()
+	handler := slogassert.New(t, slog.LevelInfo, nil)
+	defer handler.AssertEmpty()
+	spans := New(t, handler)
+
+	ctx, span := tracer.Start(context.Background(), "work")
+	spanID := span.SpanContext().SpanID().String()
+
+	for _, test := range []struct {
+		log     func()
+		failure string
+	}{
+		{
+			// no bridge, so no IDs
+			func() { slog.New(handler).InfoContext(ctx, "bare") },
+			fmt.Sprintf(`log message "bare" was logged in span "work" (%s) but has no trace_id`, spanID),
+		},
+		{
+			func() {
+				slog.New(handler).InfoContext(ctx, "wrong",
+					"trace_id", span.SpanContext().TraceID().String(),
+					"span_id", "0000000000000001")
+			},
+			fmt.Sprintf(`log message "wrong" was logged in span "work" (%s) but has span_id 0000000000000001, not %s`,
+				spanID, spanID),
+		},
+		{
+			func() { slog.New(handler).Info("orphan", "trace_id", "abc") },
+			`log message "orphan" has trace_id but was not logged in a span`,
+		},
+		{
+			func() {
+				span.End()
+				slog.New(traceAttrs{handler}).InfoContext(ctx, "stale")
+			},
+			fmt.Sprintf(`log message "stale" was logged in span "work" (%s) after it ended`, spanID),
+		},
+	} {
+		test.log()
+		for _, lm := range handler.Unasserted() {
+			err := spans.consistent(lm)
+			if err == nil {
+				t.Fatalf("no inconsistency found for %q", lm.Message)
+			}
+			got := fmt.Sprintf("log message %q %s", lm.Message, err)
+			if got != test.failure {
+				t.Fatalf("incorrect failure:\n%s\nnot\n%s", got, test.failure)
+			}
+		}
+		handler.Reset()
+	}
+}
+
+func TestFailures(t *testing.T) {
+	tracer, _ := newTracer()
+	rt := &recordingTester{}
+	handler := slogassert.New(rt, slog.LevelInfo, nil)
+	spans := New(rt, handler)
+
+	ctx, span := tracer.Start(context.Background(), "work")
+	span.End()
+	slog.New(handler).InfoContext(ctx, "late")
+
+	spans.AssertTraceConsistent()
+	spans.AssertLoggedInSpan("other")
+	if len(rt.failures) != 2 ||
+		!strings.HasSuffix(rt.failures[0], "after it ended") ||
+		rt.failures[1] != `No logs in span "other" found` {
+		t.Fatalf("incorrect failures: %#v", rt.failures)
+	}
+
+	// ignoring IDs leaves only the staleness check
+	spans.TraceIDKey = ""
+	spans.SpanIDKey = ""
+	slog.New(handler).Info("orphan", "trace_id", "abc")
+	if spans.consistent(handler.Unasserted()[1]) != nil {
+		t.Fatal("disabled ID keys were checked")
+	}
+}
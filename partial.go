@@ -0,0 +1,341 @@
+package slogassert
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sort"
+)
+
+// A ValueMatcher is a matcher for a LogMessageMatch's Attrs that can
+// explain why it does not match. MatchValue should return nil if the
+// value matches, and otherwise an error describing the mismatch.
+//
+// The partial matchers for KindAny values, such as [Fields] and
+// [ContainsElements], are ValueMatchers. Their mismatch errors
+// describe the path to the mismatched value, such as
+// ".Address.City: got "Paris"".
+type ValueMatcher interface {
+	MatchValue(slog.Value) error
+}
+
+// mismatch is an error describing where in a value a match failed.
+type mismatch struct {
+	path   string
+	reason string
+}
+
+func (m *mismatch) Error() string {
+	if m.path == "" {
+		return m.reason
+	}
+	return m.path + ": " + m.reason
+}
+
+func (m *mismatch) Is(target error) bool {
+	return target == errNoMatch
+}
+
+// matchAt matches a Go value found at the given path against a
+// matcher, with the same rules as matchAttr, prefixing any mismatch
+// with the path.
+func matchAt(path string, matcher any, v any) error {
+	err := matchAttr(matcher, slog.AnyValue(v))
+	if err == nil {
+		return nil
+	}
+
+	var m *mismatch
+	if errors.As(err, &m) {
+		return &mismatch{path + m.path, m.reason}
+	}
+	if err == errNoMatch {
+		return &mismatch{path, fmt.Sprintf("got %#v", v)}
+	}
+	return fmt.Errorf("%s: %w", path, err)
+}
+
+// indirect follows pointers and interfaces down to a concrete value.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() &&
+		(v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+type fieldsMatcher map[string]any
+
+// Fields returns a matcher for a KindAny attribute holding a struct,
+// or a pointer to one, that matches the named fields against the
+// given matchers. Fields not named are ignored.
+//
+// The matchers follow the same rules as LogMessageMatch.Attrs, with
+// the field value converted by slog.AnyValue, so plain ints can be
+// used for any integer field, and matchers like Fields may be nested.
+// Only exported fields can be matched.
+func Fields(fields map[string]any) ValueMatcher {
+	return fieldsMatcher(fields)
+}
+
+func (fm fieldsMatcher) MatchValue(val slog.Value) error {
+	v := indirect(reflect.ValueOf(val.Any()))
+	if v.Kind() != reflect.Struct {
+		return &mismatch{"", fmt.Sprintf("expected a struct, got %T", val.Any())}
+	}
+
+	for _, name := range sortedKeys(fm) {
+		field, found := v.Type().FieldByName(name)
+		if !found {
+			return &mismatch{"." + name, "no such field"}
+		}
+		if !field.IsExported() {
+			return fmt.Errorf(".%s: can not match unexported field", name)
+		}
+		fv, err := v.FieldByIndexErr(field.Index)
+		if err != nil {
+			// promoted through a nil embedded pointer
+			return &mismatch{"." + name, "nil embedded struct"}
+		}
+		err = matchAt("."+name, fm[name], fv.Interface())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type nonZeroFieldsMatcher struct {
+	expected any
+}
+
+// NonZeroFields returns a matcher for a KindAny attribute holding a
+// struct, or a pointer to one, that compares it against the expected
+// struct, ignoring all the fields that are zero in the expected
+// struct. Nested structs are compared the same way, except for those
+// with no exported fields, such as time.Time, which are compared as a
+// whole by the usual rules for attribute values.
+//
+// Only exported fields are compared.
+func NonZeroFields(expected any) ValueMatcher {
+	return nonZeroFieldsMatcher{expected}
+}
+
+func (nzf nonZeroFieldsMatcher) MatchValue(val slog.Value) error {
+	return matchNonZero("", reflect.ValueOf(nzf.expected), reflect.ValueOf(val.Any()))
+}
+
+func matchNonZero(path string, expected reflect.Value, actual reflect.Value) error {
+	expected = indirect(expected)
+	actual = indirect(actual)
+	if expected.Kind() != reflect.Struct {
+		return fmt.Errorf("%sNonZeroFields requires a struct, got %s",
+			path, expected.Kind())
+	}
+	if !actual.IsValid() || actual.Type() != expected.Type() {
+		return &mismatch{path, fmt.Sprintf("expected %s, got %s",
+			expected.Type(), describeType(actual))}
+	}
+
+	for i := 0; i < expected.NumField(); i++ {
+		field := expected.Type().Field(i)
+		if !field.IsExported() || expected.Field(i).IsZero() {
+			continue
+		}
+
+		fieldPath := path + "." + field.Name
+		if hasExportedFields(indirect(expected.Field(i))) {
+			err := matchNonZero(fieldPath, expected.Field(i), actual.Field(i))
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		err := matchAt(fieldPath, expected.Field(i).Interface(),
+			actual.Field(i).Interface())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasExportedFields returns true if v is a struct with at least one
+// exported field. Structs without any, such as time.Time, can only be
+// compared as a whole.
+func hasExportedFields(v reflect.Value) bool {
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+func describeType(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return v.Type().String()
+}
+
+// sliceElements returns the elements of a slice or array value.
+func sliceElements(val slog.Value) ([]any, error) {
+	v := indirect(reflect.ValueOf(val.Any()))
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, &mismatch{"", fmt.Sprintf("expected a slice, got %T", val.Any())}
+	}
+	elems := make([]any, v.Len())
+	for i := range elems {
+		elems[i] = v.Index(i).Interface()
+	}
+	return elems, nil
+}
+
+type containsElements []any
+
+// ContainsElements returns a matcher for a KindAny attribute holding
+// a slice or array, that matches if every one of the given matchers
+// matches some distinct element of it, in any order. The slice may
+// have other elements as well.
+//
+// The matchers follow the same rules as LogMessageMatch.Attrs.
+func ContainsElements(matchers ...any) ValueMatcher {
+	return containsElements(matchers)
+}
+
+func (ce containsElements) MatchValue(val slog.Value) error {
+	elems, err := sliceElements(val)
+	if err != nil {
+		return err
+	}
+	return assignElements(ce, elems)
+}
+
+type elementsInAnyOrder []any
+
+// ElementsInAnyOrder returns a matcher for a KindAny attribute
+// holding a slice or array, that matches if it has exactly as many
+// elements as there are matchers, and each matcher matches a
+// distinct element, in any order.
+//
+// The matchers follow the same rules as LogMessageMatch.Attrs.
+func ElementsInAnyOrder(matchers ...any) ValueMatcher {
+	return elementsInAnyOrder(matchers)
+}
+
+func (eiao elementsInAnyOrder) MatchValue(val slog.Value) error {
+	elems, err := sliceElements(val)
+	if err != nil {
+		return err
+	}
+	if len(elems) != len(eiao) {
+		return &mismatch{"", fmt.Sprintf("expected %d element(s), got %d",
+			len(eiao), len(elems))}
+	}
+	return assignElements(eiao, elems)
+}
+
+// assignElements finds an assignment of each matcher to a distinct
+// element that it matches. As matchers may match more than one
+// element, this backtracks; the slices in log messages are expected
+// to be small.
+func assignElements(matchers []any, elems []any) error {
+	used := make([]bool, len(elems))
+	var firstFailure error
+
+	var assign func(idx int) bool
+	assign = func(idx int) bool {
+		if idx == len(matchers) {
+			return true
+		}
+		matchedAny := false
+		for elemIdx, elem := range elems {
+			if used[elemIdx] {
+				continue
+			}
+			err := matchAttr(matchers[idx], slog.AnyValue(elem))
+			if err != nil {
+				if !errors.Is(err, errNoMatch) && firstFailure == nil {
+					firstFailure = err
+				}
+				continue
+			}
+			matchedAny = true
+			used[elemIdx] = true
+			if assign(idx + 1) {
+				return true
+			}
+			used[elemIdx] = false
+		}
+		if !matchedAny && firstFailure == nil {
+			firstFailure = &mismatch{
+				fmt.Sprintf("[%d]", idx),
+				fmt.Sprintf("no element matches %#v", matchers[idx]),
+			}
+		}
+		return false
+	}
+
+	if assign(0) {
+		return nil
+	}
+	if firstFailure == nil {
+		firstFailure = &mismatch{"", "no assignment of elements matches all matchers"}
+	}
+	return firstFailure
+}
+
+type mapSubset[K comparable] map[K]any
+
+// MapSubset returns a matcher for a KindAny attribute holding a map
+// with keys of type K, that matches if every key in the given map is
+// present in it, with a value matching the given matcher. The map
+// may have other keys as well.
+//
+// The matchers follow the same rules as LogMessageMatch.Attrs.
+func MapSubset[K comparable](subset map[K]any) ValueMatcher {
+	return mapSubset[K](subset)
+}
+
+func (ms mapSubset[K]) MatchValue(val slog.Value) error {
+	v := indirect(reflect.ValueOf(val.Any()))
+	if v.Kind() != reflect.Map {
+		return &mismatch{"", fmt.Sprintf("expected a map, got %T", val.Any())}
+	}
+	var zero K
+	if v.Type().Key() != reflect.TypeOf(&zero).Elem() {
+		return fmt.Errorf("MapSubset with key type %T can not match a %s",
+			zero, v.Type())
+	}
+
+	keys := make([]K, 0, len(ms))
+	for key := range ms {
+		keys = append(keys, key)
+	}
+	// deterministic order for error messages
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprintf("%#v", keys[i]) < fmt.Sprintf("%#v", keys[j])
+	})
+
+	for _, key := range keys {
+		path := fmt.Sprintf("[%#v]", key)
+		elem := v.MapIndex(reflect.ValueOf(key))
+		if !elem.IsValid() {
+			return &mismatch{path, "missing key"}
+		}
+		err := matchAt(path, ms[key], elem.Interface())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package slogassert
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type testAddress struct {
+	Street string
+	City   string
+}
+
+type testUser struct {
+	Name    string
+	Age     int
+	Address *testAddress
+	Tags    []string
+	Meta    map[string]int
+	secret  string
+}
+
+func TestPartialMatchers(t *testing.T) {
+	user := &testUser{
+		Name:    "jerf",
+		Age:     40,
+		Address: &testAddress{Street: "Main", City: "Springfield"},
+		Tags:    []string{"a", "b", "c"},
+		Meta:    map[string]int{"x": 1, "y": 2},
+		secret:  "shh",
+	}
+	val := slog.AnyValue(user)
+
+	for idx, test := range []struct {
+		matcher ValueMatcher
+		err     string
+	}{
+		{Fields(map[string]any{"Name": "jerf", "Age": 40}), ""},
+		{Fields(map[string]any{
+			"Address": Fields(map[string]any{"City": "Springfield"}),
+		}), ""},
+		{Fields(map[string]any{
+			"Address": Fields(map[string]any{"City": "Paris"}),
+		}), `.Address.City: got "Springfield"`},
+		{Fields(map[string]any{"Age": func(i int64) bool { return i > 18 }}), ""},
+		{Fields(map[string]any{"Nope": 1}), ".Nope: no such field"},
+		{Fields(map[string]any{"secret": "shh"}), ".secret: can not match unexported field"},
+		{NonZeroFields(testUser{Name: "jerf"}), ""},
+		{NonZeroFields(testUser{
+			Name:    "jerf",
+			Address: &testAddress{City: "Springfield"},
+		}), ""},
+		{NonZeroFields(&testUser{
+			Address: &testAddress{Street: "Elm"},
+		}), `.Address.Street: got "Main"`},
+		{NonZeroFields(testAddress{}), "expected slogassert.testAddress, got slogassert.testUser"},
+		{Fields(map[string]any{"Tags": ContainsElements("c", "a")}), ""},
+		{Fields(map[string]any{"Tags": ContainsElements("d")}), `.Tags[0]: no element matches "d"`},
+		{Fields(map[string]any{"Tags": ElementsInAnyOrder("c", "a", "b")}), ""},
+		{Fields(map[string]any{"Tags": ElementsInAnyOrder("c", "a")}), ".Tags: expected 2 element(s), got 3"},
+		{Fields(map[string]any{"Meta": MapSubset(map[string]any{"x": 1})}), ""},
+		{Fields(map[string]any{"Meta": MapSubset(map[string]any{"x": 2})}), `.Meta["x"]: got 1`},
+		{Fields(map[string]any{"Meta": MapSubset(map[string]any{"z": 2})}), `.Meta["z"]: missing key`},
+		{Fields(map[string]any{"Meta": MapSubset(map[int]any{1: 2})}), ".Meta: MapSubset with key type int can not match a map[string]int"},
+		{ContainsElements("a"), "expected a slice, got *slogassert.testUser"},
+		{MapSubset(map[string]any{}), "expected a map, got *slogassert.testUser"},
+	} {
+		err := test.matcher.MatchValue(val)
+		switch {
+		case test.err == "" && err != nil:
+			t.Fatalf("test %d: unexpected mismatch: %v", idx, err)
+		case test.err != "" && (err == nil || err.Error() != test.err):
+			t.Fatalf("test %d: expected %q, got %v", idx, test.err, err)
+		}
+	}
+
+	if !errors.Is(Fields(map[string]any{"Age": 1}).MatchValue(val), errNoMatch) {
+		t.Fatal("mismatch is not a no-match")
+	}
+	if errors.Is(Fields(map[string]any{"Age": "1"}).MatchValue(val), errNoMatch) {
+		t.Fatal("invalid matcher treated as a no-match")
+	}
+
+	// structs with no exported fields are compared whole, not
+	// vacuously matched by recursing into them
+	type event struct {
+		Name string
+		At   time.Time
+	}
+	logged := slog.AnyValue(event{Name: "x", At: time.Unix(0, 0)})
+	if NonZeroFields(event{Name: "x", At: time.Unix(999999, 0)}).MatchValue(logged) == nil {
+		t.Fatal("NonZeroFields matched a different time.Time")
+	}
+	if NonZeroFields(event{At: time.Unix(0, 0).UTC()}).MatchValue(logged) != nil {
+		t.Fatal("NonZeroFields did not compare times with time.Equal")
+	}
+
+	// fields promoted through a nil embedded pointer don't match,
+	// rather than panicking
+	type embedded struct {
+		*testAddress
+		Name string
+	}
+	err := Fields(map[string]any{"City": "Springfield"}).
+		MatchValue(slog.AnyValue(embedded{Name: "n"}))
+	if err == nil || err.Error() != ".City: nil embedded struct" ||
+		!errors.Is(err, errNoMatch) {
+		t.Fatalf("incorrect nil embedded struct mismatch: %v", err)
+	}
+
+	// ElementsInAnyOrder has to backtrack here
+	if ElementsInAnyOrder(
+		func(string) bool { return true },
+		"a",
+	).MatchValue(slog.AnyValue([]string{"a", "b"})) != nil {
+		t.Fatal("ElementsInAnyOrder did not backtrack")
+	}
+
+	handler := New(t, slog.LevelWarn, nil)
+	defer handler.AssertEmpty()
+	slog.New(handler).Warn(testWarning, "user", user)
+	handler.AssertPrecise(LogMessageMatch{
+		Message: testWarning,
+		Level:   slog.LevelWarn,
+		Attrs: map[string]any{
+			"user": NonZeroFields(testUser{Name: "jerf"}),
+		},
+	})
+}
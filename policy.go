@@ -0,0 +1,57 @@
+package slogassert
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// An EmptyPolicy configures how [Handler.AssertEmpty] treats
+// unasserted log messages, by level.
+//
+// Unasserted messages at or above the Assert level fail the
+// test. Messages below that, but at or above the Report level, are
+// printed out through the Handler's Reporter as a note, but do not
+// fail the test. Messages below both are ignored.
+//
+// A nil Assert means all levels must be asserted, which is the
+// default behavior. A nil Report means nothing below the Assert level
+// is reported.
+//
+// For example, to require Warn and above to be asserted, print out
+// Info messages, and ignore Debug messages:
+//
+//	slogassert.EmptyPolicy{
+//		Assert: slog.LevelWarn,
+//		Report: slog.LevelInfo,
+//	}
+//
+// This allows a Handler to capture at Debug level for diagnostic
+// purposes without every Debug message needing to be asserted.
+type EmptyPolicy struct {
+	Assert slog.Leveler
+	Report slog.Leveler
+}
+
+// SetEmptyPolicy sets the EmptyPolicy used by [Handler.AssertEmpty].
+func (h *Handler) SetEmptyPolicy(policy EmptyPolicy) {
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	root.emptyPolicy = policy
+}
+
+func (ep EmptyPolicy) mustAssert(level slog.Level) bool {
+	return ep.Assert == nil || level >= ep.Assert.Level()
+}
+
+func (ep EmptyPolicy) report(level slog.Level) bool {
+	return ep.Report != nil && level >= ep.Report.Level()
+}
+
+func (ep EmptyPolicy) reportTitle(count int) string {
+	return fmt.Sprintf(
+		"note: %d unasserted log message(s) below level %s, not failing:",
+		count, ep.Assert.Level(),
+	)
+}
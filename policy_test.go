@@ -0,0 +1,52 @@
+package slogassert
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestEmptyPolicy(t *testing.T) {
+	rt := &recordingTester{}
+	cr := &capturingReporter{}
+	handler := New(rt, slog.LevelDebug, nil)
+	handler.SetReporter(cr)
+	handler.SetEmptyPolicy(EmptyPolicy{
+		Assert: slog.LevelWarn,
+		Report: slog.LevelInfo,
+	})
+	log := slog.New(handler)
+
+	log.Debug("ignored")
+	log.Info("reported")
+	handler.AssertEmpty()
+
+	if len(rt.failures) != 0 {
+		t.Fatalf("policy did not prevent failure: %v", rt.failures)
+	}
+	if len(cr.titles) != 1 ||
+		cr.titles[0] != "note: 1 unasserted log message(s) below level WARN, not failing:" ||
+		len(cr.msgs) != 1 || cr.msgs[0].Message != "reported" {
+		t.Fatalf("incorrect report: %v %#v", cr.titles, cr.msgs)
+	}
+
+	log.Warn(testWarning)
+	handler.AssertEmpty()
+	if len(rt.failures) != 1 {
+		t.Fatal("policy did not fail on Warn")
+	}
+
+	// without a Report level, nothing is reported
+	handler.Reset()
+	cr.titles = nil
+	handler.SetEmptyPolicy(EmptyPolicy{Assert: slog.LevelWarn})
+	log.Info("not reported")
+	handler.AssertEmpty()
+	if len(cr.titles) != 0 || len(rt.failures) != 1 {
+		t.Fatal("incorrect behavior without Report level")
+	}
+}
+
+func TestDefaultEmptyPolicy(t *testing.T) {
+	NewDefault(t, WithAssertEmpty(EmptyPolicy{Assert: slog.LevelWarn}))
+	slog.Info("not asserted")
+}
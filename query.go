@@ -0,0 +1,459 @@
+package slogassert
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// A Query is a compiled textual query against log messages, as
+// created by [ParseQuery].
+//
+// A query is a whitespace-separated list of terms, all of which must
+// match for the query to match. For example:
+//
+//	level>=WARN msg~"timed out" request.user_id=42 !err
+//
+// matches any message at Warn level or above, whose message matches
+// the regular expression "timed out", with a request.user_id
+// attribute equal to 42, and no err attribute.
+//
+// The terms are:
+//
+//   - level OP LEVEL: compares the level of the message. OP is one of
+//     =, !=, <, <=, >, >=. LEVEL is anything slog.Level can
+//     unmarshal, such as WARN or INFO+2.
+//   - msg=TEXT, msg!=TEXT: compares the message for equality.
+//   - msg~REGEXP: matches the message against a regular expression.
+//   - KEY: the attribute is present.
+//   - !KEY: the attribute is absent.
+//   - KEY=VALUE, KEY!=VALUE: compares the attribute to the value,
+//     which is parsed according to the kind of the attribute, so
+//     count=5 matches an Int64, Uint64, or Float64 of 5, and
+//     d=1.5s matches a Duration.
+//   - KEY<VALUE, KEY<=VALUE, KEY>VALUE, KEY>=VALUE: compares numeric,
+//     duration, and time attributes. Other attributes, such as bools
+//     and strings, never match these.
+//   - KEY~REGEXP: matches the attribute's string form against a
+//     regular expression.
+//
+// KEYs are the same as the keys in LogMessageMatch.Attrs: groups
+// joined by dots, with dots in the keys themselves
+// backslash-escaped. As level and msg are reserved for the message's
+// level and message, attributes with those keys are written \level
+// and \msg, and a bare level or msg without an operator is an error
+// rather than a check for the attribute. Values and regular
+// expressions may be double-quoted, with Go string escapes, to
+// include spaces; times are written in RFC 3339 format.
+type Query struct {
+	text  string
+	terms []func(LogMessage) bool
+}
+
+// ParseQuery parses the given query text into a Query. See [Query]
+// for the syntax.
+func ParseQuery(text string) (*Query, error) {
+	q := &Query{text: text}
+	p := &queryParser{text: text}
+
+	for {
+		p.skipSpace()
+		if p.done() {
+			break
+		}
+		term, err := p.term()
+		if err != nil {
+			return nil, fmt.Errorf("invalid query %q: %w", text, err)
+		}
+		q.terms = append(q.terms, term)
+	}
+
+	return q, nil
+}
+
+// Matches returns true if the log message matches the query.
+func (q *Query) Matches(lm LogMessage) bool {
+	for _, term := range q.terms {
+		if !term(lm) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the text of the query.
+func (q *Query) String() string {
+	return q.text
+}
+
+type queryParser struct {
+	text string
+	pos  int
+}
+
+func (p *queryParser) done() bool {
+	return p.pos >= len(p.text)
+}
+
+func (p *queryParser) peek() byte {
+	return p.text[p.pos]
+}
+
+func (p *queryParser) skipSpace() {
+	for !p.done() && unicode.IsSpace(rune(p.peek())) {
+		p.pos++
+	}
+}
+
+func isOperatorByte(b byte) bool {
+	return b == '=' || b == '!' || b == '<' || b == '>' || b == '~'
+}
+
+func (p *queryParser) term() (func(LogMessage) bool, error) {
+	negated := false
+	if p.peek() == '!' {
+		negated = true
+		p.pos++
+	}
+
+	start := p.pos
+	key := p.key()
+	if key == "" {
+		return nil, fmt.Errorf("missing key at offset %d", start)
+	}
+
+	reserved := key == "level" || key == "msg"
+	if key == `\level` || key == `\msg` {
+		// an attribute whose key collides with a reserved word
+		key = key[1:]
+	}
+
+	if p.done() || unicode.IsSpace(rune(p.peek())) {
+		if reserved {
+			return nil, fmt.Errorf("%q requires an operator; use \\%s for an attribute named %s",
+				key, key, key)
+		}
+		return func(lm LogMessage) bool {
+			_, present := lm.Attrs[key]
+			return present != negated
+		}, nil
+	}
+	if negated {
+		return nil, fmt.Errorf("\"!%s\" can not be followed by an operator", key)
+	}
+
+	op := p.operator()
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case reserved && key == "level":
+		return levelTerm(op, value)
+	case reserved && key == "msg":
+		return messageTerm(op, value)
+	default:
+		return attrTerm(key, op, value)
+	}
+}
+
+func (p *queryParser) key() string {
+	start := p.pos
+	for !p.done() {
+		b := p.peek()
+		if b == '\\' && p.pos+1 < len(p.text) {
+			p.pos += 2
+			continue
+		}
+		if isOperatorByte(b) || unicode.IsSpace(rune(b)) {
+			break
+		}
+		p.pos++
+	}
+	return p.text[start:p.pos]
+}
+
+func (p *queryParser) operator() string {
+	start := p.pos
+	for !p.done() && isOperatorByte(p.peek()) && p.pos-start < 2 {
+		p.pos++
+	}
+	return p.text[start:p.pos]
+}
+
+func (p *queryParser) value() (string, error) {
+	if p.done() || p.peek() != '"' {
+		start := p.pos
+		for !p.done() && !unicode.IsSpace(rune(p.peek())) {
+			p.pos++
+		}
+		return p.text[start:p.pos], nil
+	}
+
+	start := p.pos
+	p.pos++
+	for !p.done() {
+		switch p.peek() {
+		case '\\':
+			p.pos += 2
+			continue
+		case '"':
+			p.pos++
+			return strconv.Unquote(p.text[start:p.pos])
+		}
+		p.pos++
+	}
+	return "", fmt.Errorf("unterminated string at offset %d", start)
+}
+
+// compare converts a comparison result into whether the operator is
+// satisfied, or false for operators that don't apply.
+func compare(op string, cmp int) bool {
+	switch op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	default:
+		return false
+	}
+}
+
+func validOperator(op string, allowed ...string) error {
+	for _, a := range allowed {
+		if op == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid operator %q, expected one of %s",
+		op, strings.Join(allowed, " "))
+}
+
+func levelTerm(op string, value string) (func(LogMessage) bool, error) {
+	err := validOperator(op, "=", "!=", "<", "<=", ">", ">=")
+	if err != nil {
+		return nil, err
+	}
+	var level slog.Level
+	err = level.UnmarshalText([]byte(value))
+	if err != nil {
+		return nil, err
+	}
+	return func(lm LogMessage) bool {
+		return compare(op, cmpOrdered(lm.Level, level))
+	}, nil
+}
+
+func messageTerm(op string, value string) (func(LogMessage) bool, error) {
+	err := validOperator(op, "=", "!=", "~")
+	if err != nil {
+		return nil, err
+	}
+	if op == "~" {
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return nil, err
+		}
+		return func(lm LogMessage) bool {
+			return re.MatchString(lm.Message)
+		}, nil
+	}
+	return func(lm LogMessage) bool {
+		return (lm.Message == value) == (op == "=")
+	}, nil
+}
+
+func attrTerm(key string, op string, value string) (func(LogMessage) bool, error) {
+	err := validOperator(op, "=", "!=", "<", "<=", ">", ">=", "~")
+	if err != nil {
+		return nil, err
+	}
+	if op == "~" {
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return nil, err
+		}
+		return func(lm LogMessage) bool {
+			val, present := lm.Attrs[key]
+			return present && re.MatchString(val.String())
+		}, nil
+	}
+
+	return func(lm LogMessage) bool {
+		val, present := lm.Attrs[key]
+		if !present {
+			return false
+		}
+		if op != "=" && op != "!=" && !orderedKind(val.Kind()) {
+			// bools, strings, and any values have no order
+			return false
+		}
+		cmp, comparable := compareValue(val, value)
+		if !comparable {
+			// values that can't even be parsed as the
+			// right kind are certainly not equal
+			return op == "!="
+		}
+		return compare(op, cmp)
+	}, nil
+}
+
+// orderedKind returns whether the <, <=, >, and >= operators apply
+// to values of the kind.
+func orderedKind(kind slog.Kind) bool {
+	switch kind {
+	case slog.KindInt64, slog.KindUint64, slog.KindFloat64,
+		slog.KindDuration, slog.KindTime:
+		return true
+	default:
+		return false
+	}
+}
+
+type ordered interface {
+	~int | ~int64 | ~uint64 | ~float64 | ~string
+}
+
+func cmpOrdered[T ordered](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareValue compares the attribute value to the query's text,
+// parsed as appropriate for the value's kind.
+func compareValue(val slog.Value, text string) (int, bool) {
+	switch val.Kind() {
+	case slog.KindInt64, slog.KindUint64, slog.KindFloat64:
+		return compareNumeric(val, text)
+	case slog.KindBool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return 0, false
+		}
+		if b == val.Bool() {
+			return 0, true
+		}
+		return 1, true
+	case slog.KindDuration:
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return 0, false
+		}
+		return cmpOrdered(val.Duration(), d), true
+	case slog.KindTime:
+		t, err := time.Parse(time.RFC3339Nano, text)
+		if err != nil {
+			return 0, false
+		}
+		return val.Time().Compare(t), true
+	default:
+		return cmpOrdered(val.String(), text), true
+	}
+}
+
+func compareNumeric(val slog.Value, text string) (int, bool) {
+	switch val.Kind() {
+	case slog.KindInt64:
+		i, err := strconv.ParseInt(text, 10, 64)
+		if err == nil {
+			return cmpOrdered(val.Int64(), i), true
+		}
+	case slog.KindUint64:
+		u, err := strconv.ParseUint(text, 10, 64)
+		if err == nil {
+			return cmpOrdered(val.Uint64(), u), true
+		}
+	}
+
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, false
+	}
+	switch val.Kind() {
+	case slog.KindInt64:
+		return cmpOrdered(float64(val.Int64()), f), true
+	case slog.KindUint64:
+		return cmpOrdered(float64(val.Uint64()), f), true
+	default:
+		return cmpOrdered(val.Float64(), f), true
+	}
+}
+
+// parseQuery parses the query, failing the test if it is invalid.
+func (h *Handler) parseQuery(text string) *Query {
+	h.t.Helper()
+	q, err := ParseQuery(text)
+	if err != nil {
+		h.t.Fatalf("%v", err)
+		return nil
+	}
+	return q
+}
+
+// AssertQuery asserts the first log message that matches the given
+// query. See [Query] for the syntax. An invalid query fails the test.
+func (h *Handler) AssertQuery(query string) {
+	h.t.Helper()
+	q := h.parseQuery(query)
+	if q == nil {
+		return
+	}
+	matches := h.Assert(trueOnlyOnce(q.Matches))
+	if matches == 0 {
+		h.Fail("No logs matching query %q were found", query)
+	}
+}
+
+// AssertSomeQuery asserts all the messages in the log that match the
+// given query, returning how many there were. If there are none, the
+// test fails. See [Query] for the syntax.
+func (h *Handler) AssertSomeQuery(query string) int {
+	h.t.Helper()
+	q := h.parseQuery(query)
+	if q == nil {
+		return 0
+	}
+	matches := h.Assert(q.Matches)
+	if matches == 0 {
+		h.Fail("No logs matching query %q were found", query)
+	}
+	return matches
+}
+
+// Find returns copies of all the unasserted log messages that match
+// the given query, without asserting them. See [Query] for the
+// syntax. An invalid query fails the test.
+func (h *Handler) Find(query string) []LogMessage {
+	h.t.Helper()
+	q := h.parseQuery(query)
+	if q == nil {
+		return nil
+	}
+
+	found := []LogMessage{}
+	for _, lm := range h.Unasserted() {
+		if q.Matches(lm) {
+			found = append(found, lm)
+		}
+	}
+	return found
+}
@@ -0,0 +1,145 @@
+package slogassert
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuery(t *testing.T) {
+	at := time.Date(2024, time.July, 25, 0, 0, 0, 0, time.UTC)
+	lm := LogMessage{
+		Message: "request timed out",
+		Level:   slog.LevelWarn,
+		Attrs: map[string]slog.Value{
+			"request.user_id": slog.Int64Value(42),
+			"request.size":    slog.Uint64Value(10),
+			"ratio":           slog.Float64Value(0.5),
+			"ok":              slog.BoolValue(false),
+			"d":               slog.DurationValue(1500 * time.Millisecond),
+			"at":              slog.TimeValue(at),
+			"url":             slog.StringValue("/a b"),
+			"a\\.b":           slog.StringValue("dotted"),
+			"err":             slog.AnyValue(errors.New("oops")),
+			"msg":             slog.StringValue("attr"),
+		},
+	}
+
+	for _, test := range []struct {
+		query   string
+		matches bool
+	}{
+		{"", true},
+		{`level>=WARN msg~"timed out" request.user_id=42 !missing`, true},
+		{"level>=WARN !err", false},
+		{"level=WARN", true},
+		{"level!=WARN", false},
+		{"level<ERROR level>INFO level<=WARN+0", true},
+		{"level>WARN", false},
+		{`msg="request timed out"`, true},
+		{`msg!="request timed out"`, false},
+		{"msg~^request", true},
+		{"msg~^timed", false},
+		{"request.user_id>40 request.user_id<=42", true},
+		{"request.user_id=42.0", true},
+		{"request.user_id=41", false},
+		{"request.user_id!=41", true},
+		{"request.user_id=notanumber", false},
+		{"request.user_id!=notanumber", true},
+		{"request.size=10 request.size>=9.5", true},
+		{"ratio<1 ratio=0.5", true},
+		{"ok=false", true},
+		{"ok=true", false},
+		{"ok=maybe", false},
+		{"d>1s d<2s d=1.5s", true},
+		{"d=nope", false},
+		{"at=2024-07-25T00:00:00Z at<2025-01-01T00:00:00Z", true},
+		{"at=yesterday", false},
+		{`url="/a b"`, true},
+		{`url~"a b$"`, true},
+		{`url>/a`, false},
+		{`url<"~"`, false},
+		{"ok>true", false},
+		{"ok<true", false},
+		{"ok>=false", false},
+		{"err<=oops", false},
+		{`a\.b=dotted`, true},
+		{"err=oops err~^oo", true},
+		{"err", true},
+		{"missing=1", false},
+		{"missing~.", false},
+		{`\msg \msg=attr msg="request timed out"`, true},
+		{`\msg="request timed out"`, false},
+		{`!\level`, true},
+		{`\level=WARN`, false},
+	} {
+		q, err := ParseQuery(test.query)
+		if err != nil {
+			t.Fatalf("could not parse %q: %v", test.query, err)
+		}
+		if q.Matches(lm) != test.matches {
+			t.Fatalf("query %q: expected match %v", test.query, test.matches)
+		}
+		if q.String() != test.query {
+			t.Fatal("incorrect String for query")
+		}
+	}
+
+	for _, bad := range []string{
+		"=5",
+		"!a=5",
+		"a==5",
+		"level~WARN",
+		"level=LOUD",
+		"msg>a",
+		"msg~(",
+		"a~(",
+		`a="unterminated`,
+		`a="bad \q escape"`,
+		"msg",
+		"!level",
+	} {
+		_, err := ParseQuery(bad)
+		if err == nil {
+			t.Fatalf("query %q should not parse", bad)
+		}
+		if !strings.Contains(err.Error(), "invalid query") {
+			t.Fatalf("query %q: error does not echo the query: %v", bad, err)
+		}
+	}
+}
+
+func TestQueryAssertions(t *testing.T) {
+	handler := New(t, slog.LevelDebug, nil)
+	defer handler.AssertEmpty()
+	log := slog.New(handler)
+
+	log.Warn("timed out", "user_id", 1)
+	log.Warn("timed out", "user_id", 2)
+	log.Info("fine", "user_id", 3)
+
+	found := handler.Find("level>=WARN")
+	if len(found) != 2 {
+		t.Fatalf("incorrect Find result: %d", len(found))
+	}
+	handler.AssertQuery("user_id=2")
+	if handler.AssertSomeQuery("user_id<5") != 2 {
+		t.Fatal("incorrect AssertSomeQuery result")
+	}
+
+	rt := &recordingTester{}
+	handler.t = rt
+	handler.AssertQuery("a==1")
+	handler.AssertSomeQuery("a==1")
+	handler.Find("a==1")
+	handler.AssertQuery("user_id=5")
+	handler.AssertSomeQuery("user_id=5")
+	handler.t = t
+	if len(rt.failures) != 5 ||
+		!strings.Contains(rt.failures[0], `invalid query "a==1"`) ||
+		!strings.Contains(rt.failures[3], `No logs matching query "user_id=5"`) {
+		t.Fatalf("incorrect failures: %v", rt.failures)
+	}
+}
@@ -0,0 +1,125 @@
+package slogassert
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// A Reporter receives the log messages that slogassert prints out to
+// explain a test failure, such as the unasserted messages when an
+// assertion fails, or the shadow buffer (see [Handler.SetShadow]).
+//
+// The title describes the messages, such as "3 unasserted log
+// message(s):". Report is not called with an empty slice of messages.
+//
+// Report is called before the test is failed, so implementations
+// must not stop the test themselves.
+type Reporter interface {
+	Report(title string, msgs []LogMessage)
+}
+
+// outputer is implemented by testing.TB in Go 1.25 and later.
+type outputer interface {
+	Output() io.Writer
+}
+
+type testReporter struct {
+	t        Tester
+	fallback io.Writer
+}
+
+// TestReporter returns a Reporter that prints through the given
+// Tester, so that the output is attributed to the right test under
+// parallel tests and "go test -json".
+//
+// If the Tester has an Output method, as testing.TB has since Go 1.25,
+// the output is written to that. Otherwise, if it has a Logf method,
+// the output is logged through that. If it has neither, the output
+// is written to the fallback writer.
+//
+// This is the default Reporter for a Handler, with a fallback of
+// os.Stderr.
+func TestReporter(t Tester, fallback io.Writer) Reporter {
+	return testReporter{t, fallback}
+}
+
+func (tr testReporter) Report(title string, msgs []LogMessage) {
+	tr.t.Helper()
+	out := renderReport(title, msgs)
+
+	switch t := tr.t.(type) {
+	case outputer:
+		_, _ = io.WriteString(t.Output(), out)
+	case logfer:
+		t.Logf("%s", out)
+	default:
+		_, _ = io.WriteString(tr.fallback, out)
+	}
+}
+
+type writerReporter struct {
+	w io.Writer
+}
+
+// WriterReporter returns a Reporter that prints to the given writer.
+func WriterReporter(w io.Writer) Reporter {
+	return writerReporter{w}
+}
+
+func (wr writerReporter) Report(title string, msgs []LogMessage) {
+	_, _ = io.WriteString(wr.w, renderReport(title, msgs))
+}
+
+func renderReport(title string, msgs []LogMessage) string {
+	out := &strings.Builder{}
+	out.WriteString(title)
+	out.WriteString("\n")
+	for _, lm := range msgs {
+		lm.Print(out)
+	}
+	return out.String()
+}
+
+// SetReporter sets the Reporter used to print out log messages when
+// a test fails. Passing nil restores the default, which is
+// TestReporter(t, os.Stderr) on the Handler's Tester.
+func (h *Handler) SetReporter(reporter Reporter) {
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	root.reporter = reporter
+}
+
+// report sends the messages to the root's Reporter, or if there is
+// none, through this handler's Tester, which may differ from the
+// root's for a handler from WithTester. It must NOT be called under
+// the handler lock.
+func (h *Handler) report(title string, msgs []LogMessage) {
+	h.t.Helper()
+	if len(msgs) == 0 {
+		return
+	}
+
+	root := h.root()
+	root.m.Lock()
+	reporter := root.reporter
+	root.m.Unlock()
+
+	if reporter == nil {
+		reporter = TestReporter(h.t, os.Stderr)
+	}
+	reporter.Report(title, msgs)
+}
+
+// reportUnasserted reports all the currently unasserted messages.
+func (h *Handler) reportUnasserted() {
+	h.t.Helper()
+	msgs := h.Unasserted()
+	h.report(
+		fmt.Sprintf("%d unasserted log message(s):", len(msgs)),
+		msgs,
+	)
+}
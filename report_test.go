@@ -0,0 +1,107 @@
+package slogassert
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// outputTester is a recordingTester with an Output method.
+type outputTester struct {
+	recordingTester
+	out strings.Builder
+}
+
+func (ot *outputTester) Output() io.Writer {
+	return &ot.out
+}
+
+type capturingReporter struct {
+	titles []string
+	msgs   []LogMessage
+}
+
+func (cr *capturingReporter) Report(title string, msgs []LogMessage) {
+	cr.titles = append(cr.titles, title)
+	cr.msgs = append(cr.msgs, msgs...)
+}
+
+func TestFailReportsRootMessages(t *testing.T) {
+	rt := &recordingTester{}
+	handler := New(rt, slog.LevelWarn, nil)
+	cr := &capturingReporter{}
+	handler.SetReporter(cr)
+
+	log := slog.New(handler)
+	log.Warn(testWarning)
+	log.Warn(test2)
+
+	child := handler.WithGroup("g").(*Handler)
+	child.AssertMessage("missing")
+
+	if len(rt.failures) != 1 {
+		t.Fatalf("incorrect failures: %v", rt.failures)
+	}
+	if len(cr.titles) != 1 || cr.titles[0] != "2 unasserted log message(s):" ||
+		len(cr.msgs) != 2 || cr.msgs[0].Message != testWarning {
+		t.Fatalf("incorrect report: %v %#v", cr.titles, cr.msgs)
+	}
+
+	// nothing unasserted means nothing reported
+	handler.Reset()
+	handler.Fail("failed")
+	if len(cr.titles) != 1 {
+		t.Fatal("empty report made")
+	}
+}
+
+func TestTestReporter(t *testing.T) {
+	msgs := []LogMessage{{Message: testWarning, Level: slog.LevelWarn}}
+
+	ot := &outputTester{}
+	TestReporter(ot, nil).Report("title", msgs)
+	if !strings.HasPrefix(ot.out.String(), "title\n--------\nmessage:    test warning") {
+		t.Fatalf("incorrect Output report: %q", ot.out.String())
+	}
+
+	lt := &loggingTester{}
+	TestReporter(lt, nil).Report("title", msgs)
+	if len(lt.logs) != 1 || !strings.HasPrefix(lt.logs[0], "title\n") {
+		t.Fatalf("incorrect Logf report: %v", lt.logs)
+	}
+
+	fallback := &strings.Builder{}
+	TestReporter(&recordingTester{}, fallback).Report("title", msgs)
+	if !strings.HasPrefix(fallback.String(), "title\n") {
+		t.Fatalf("incorrect fallback report: %q", fallback.String())
+	}
+
+	w := &strings.Builder{}
+	WriterReporter(w).Report("title", msgs)
+	if w.String() != fallback.String() {
+		t.Fatalf("incorrect writer report: %q", w.String())
+	}
+}
+
+func TestDefaultReporter(t *testing.T) {
+	w := &strings.Builder{}
+	rt := &recordingTester{}
+	handler := New(rt, slog.LevelWarn, nil)
+	handler.SetReporter(WriterReporter(w))
+	slog.New(handler).Warn(testWarning)
+	handler.AssertEmpty()
+
+	if !strings.Contains(w.String(), "1 unasserted log message(s):") ||
+		len(rt.failures) != 1 {
+		t.Fatalf("incorrect failure output: %q %v", w.String(), rt.failures)
+	}
+
+	// and the default reporter is used through NewDefault
+	cr := &capturingReporter{}
+	handler = NewDefault(t, WithReporter(cr))
+	handler.report("title", []LogMessage{{}})
+	if len(cr.titles) != 1 {
+		t.Fatal("WithReporter not applied")
+	}
+}
@@ -58,6 +58,40 @@ type Handler struct {
 
 	m           sync.Mutex
 	logMessages []LogMessage
+	reporter    Reporter
+	emptyPolicy EmptyPolicy
+
+	capacity int
+	overflow OverflowPolicy
+	dropped  map[slog.Level]int
+
+	// nil unless statistics are being collected
+	stats *Stats
+
+	extractors []contextExtractor
+
+	faults  []*injectedFault
+	faulted []LogMessage
+	ignores []*ignoreRule
+
+	expectations  []*Expectation
+	expectCleanup bool
+	strict        slog.Leveler
+
+	// handlers derived from this one, if the root is recording the
+	// tree, and how many records this handler has handled; both
+	// protected by the root's lock
+	recordTree bool
+	children   []*Handler
+	handled    int
+
+	enabledCalls map[EnabledCall]int
+	resolutions  map[resolution]int
+
+	echo          bool
+	shadowLeveler slog.Leveler
+	shadow        []LogMessage
+	shadowCleanup bool
 
 	t Tester
 }
@@ -90,6 +124,10 @@ func New(t Tester, leveler slog.Leveler, wrapped slog.Handler) *Handler {
 		attrs:   &groupedAttrs{groups: map[string]*groupedAttrs{}},
 		t:       t,
 		wrapped: wrapped,
+
+		enabledCalls: map[EnabledCall]int{},
+		resolutions:  map[resolution]int{},
+		dropped:      map[slog.Level]int{},
 	}
 	return handler
 }
@@ -110,6 +148,10 @@ func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
 // WithGroup implements slog.Handler, creating a new handler that will group
 // everything into the given group.
 func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
 	handler := h.child()
 	handler.currentGroup = append(append([]string{},
 		h.currentGroup...), name)
@@ -121,10 +163,41 @@ func (h *Handler) WithGroup(name string) slog.Handler {
 	return handler
 }
 
+// WithTester returns a Handler that shares everything with this one,
+// including its recorded messages, but reports assertion failures to
+// the given Tester instead. Unless a Reporter has been set, the log
+// messages printed to explain a failure also go to that Tester.
+//
+// This is for integrating with test frameworks that report failures
+// in their own way, such as testify's assert package, whose Tester
+// would record the failure rather than stopping the test. Unlike
+// WithAttrs and WithGroup, the result does not appear in
+// [Handler.Tree].
+func (h *Handler) WithTester(t Tester) *Handler {
+	return &Handler{
+		parent:       h,
+		currentGroup: append([]string{}, h.currentGroup...),
+		attrs:        h.attrs.clone(),
+		leveler:      h.leveler,
+		wrapped:      h.wrapped,
+		t:            t,
+	}
+}
+
 // Enabled implements slog.Handler, reporting back to slog whether or
 // not the handler is enabled for this level of log message.
 func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
-	return level >= h.leveler.Level()
+	enabled := level >= h.leveler.Level()
+
+	root := h.root()
+	root.m.Lock()
+	if !enabled && root.shadowed(level) {
+		enabled = true
+	}
+	root.enabledCalls[EnabledCall{level, enabled}]++
+	root.m.Unlock()
+
+	return enabled
 }
 
 // Handle implements slog.Handler, recording a log message into the
@@ -138,14 +211,26 @@ func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
 		Time:       record.Time,
 	}
 
+	resolved := []string{}
+
 	var f func(group []string, attr slog.Attr) bool
 	f = func(group []string, attr slog.Attr) bool {
+		// slog.Handler requires empty attrs be ignored
+		if attr.Equal(slog.Attr{}) {
+			return true
+		}
+		if attr.Value.Kind() == slog.KindLogValuer {
+			resolved = append(resolved, encgroups(group, attr.Key))
+		}
 		val := attr.Value.Resolve()
 		switch val.Kind() {
 		case slog.KindGroup:
-			groupName := attr.Key
 			attrs := val.Group()
-			newGroups := append(append([]string{}, group...), groupName)
+			newGroups := group
+			// groups with an empty key are inlined
+			if attr.Key != "" {
+				newGroups = append(append([]string{}, group...), attr.Key)
+			}
 			for _, attr := range attrs {
 				f(newGroups, attr)
 			}
@@ -161,19 +246,97 @@ func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
 	})
 
 	root := h.root()
-	root.m.Lock()
-	h.attrs.runOn(f)
+	var (
+		fault   *injectedFault
+		shadow  bool
+		echo    bool
+		failure string
+	)
+	func() {
+		root.m.Lock()
+		// the context extractors and the matchers of ignore rules,
+		// expectations, and faults are user code that may panic,
+		// which must not leave the handler locked
+		defer root.m.Unlock()
+
+		h.attrs.runOn(f)
+		lm.Context = root.extractContext(ctx)
+		h.handled++
+		if root.stats != nil {
+			root.stats.add(lm, callSite(record.PC))
+		}
 
-	root.logMessages = append(root.logMessages, lm)
-	root.m.Unlock()
+		for _, key := range resolved {
+			root.resolutions[resolution{key, lm.Level}]++
+		}
 
-	if h.wrapped != nil {
+		shadow = lm.Level < h.leveler.Level() && root.shadowed(lm.Level)
+		fault, failure = root.route(lm, shadow)
+		echo = root.echo
+	}()
+
+	if echo {
+		l, isLogfer := root.t.(logfer)
+		if isLogfer {
+			l.Logf("%s", lm.echoLine())
+		}
+	}
+	if failure != "" {
+		root.failFromHandle(failure, record.PC, lm.Stacktrace)
+	}
+
+	if fault != nil {
+		time.Sleep(fault.Latency)
+		if fault.Panic != nil {
+			panic(fault.Panic)
+		}
+		if fault.Err != nil {
+			return fault.Err
+		}
+	}
+
+	if h.wrapped != nil && !shadow {
 		return h.wrapped.Handle(ctx, record)
 	}
 
 	return nil
 }
 
+// route stores the log message in the appropriate place in the root
+// handler. It returns the injected fault to apply, if any, and a
+// failure to report, if any.
+//
+// should be run only under handler lock
+func (h *Handler) route(lm LogMessage, shadow bool) (*injectedFault, string) {
+	fault := h.findFault(lm)
+	if fault != nil && fault.fails() {
+		h.faulted = h.appendBounded(h.faulted, lm)
+		return fault, ""
+	}
+
+	if h.ignored(lm) {
+		return fault, ""
+	}
+
+	expected, failure := h.expected(lm)
+	if expected {
+		return fault, failure
+	}
+
+	failure = h.strictFailure(lm)
+	if failure != "" {
+		return fault, failure
+	}
+
+	if shadow {
+		h.shadow = h.appendBounded(h.shadow, lm)
+		return fault, ""
+	}
+
+	failure = h.capture(lm)
+	return fault, failure
+}
+
 func (h *Handler) root() *Handler {
 	for h.parent != nil {
 		h = h.parent
@@ -182,13 +345,23 @@ func (h *Handler) root() *Handler {
 }
 
 func (h *Handler) child() *Handler {
-	return &Handler{
+	child := &Handler{
 		parent:       h,
 		currentGroup: append([]string{}, h.currentGroup...),
 		attrs:        h.attrs.clone(),
 		leveler:      h.leveler,
 		wrapped:      h.wrapped,
+		t:            h.t,
 	}
+
+	root := h.root()
+	root.m.Lock()
+	if root.recordTree {
+		h.children = append(h.children, child)
+	}
+	root.m.Unlock()
+
+	return child
 }
 
 // LogMessage is a struct for storing the log messages picked up by
@@ -197,11 +370,15 @@ type LogMessage struct {
 	Message    string
 	Level      slog.Level
 	Stacktrace string
-	// key is the slash-encoded group path to this value
+	// key is the slash-encoded group path to this value; see Key
+	// and SplitKey, and Get for retrieving typed values
 	Attrs map[string]slog.Value
 	// this package deliberately ignores this, but passing
 	// testing/slogtest requires us to store this
 	Time time.Time
+	// the values pulled out of the context passed to Handle, by
+	// extractor name; see Handler.ExtractContext
+	Context map[string]any
 }
 
 // Print is a default method that can dump a LogMessage out to a
@@ -213,12 +390,7 @@ func (lm *LogMessage) Print(w io.Writer) {
 	msg.WriteString("\nlevel:      ")
 	msg.WriteString(lm.Level.String())
 	msg.WriteString("\nattributes:\n")
-	keys := []string{}
-	for attrKey := range lm.Attrs {
-		keys = append(keys, attrKey)
-	}
-	sort.Strings(keys)
-	for _, key := range keys {
+	for _, key := range sortedKeys(lm.Attrs) {
 		val := lm.Attrs[key]
 		msg.WriteString("  ")
 		msg.WriteString(key)
@@ -227,6 +399,15 @@ func (lm *LogMessage) Print(w io.Writer) {
 		msg.WriteString(") ")
 		msg.WriteString(fmt.Sprintf("%v", val.Any()))
 		msg.WriteString("\n")
+		if err, isErr := attrError(val); isErr {
+			writeErrorChain(&msg, err)
+		}
+	}
+	if len(lm.Context) > 0 {
+		msg.WriteString("context:\n")
+		for _, name := range sortedKeys(lm.Context) {
+			fmt.Fprintf(&msg, "  %s -> %v\n", name, lm.Context[name])
+		}
 	}
 	msg.WriteString("\nstack trace:\n")
 	msg.WriteString(lm.Stacktrace)
@@ -241,7 +422,38 @@ func (lm *LogMessage) clone() LogMessage {
 		Stacktrace: lm.Stacktrace,
 		Time:       lm.Time,
 		Attrs:      maps.Clone(lm.Attrs),
+		Context:    maps.Clone(lm.Context),
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// eachKey calls f on each key of the map until it returns an error,
+// in sorted order if sorted is true.
+func eachKey[V any](m map[string]V, sorted bool, f func(string) error) error {
+	if sorted {
+		for _, key := range sortedKeys(m) {
+			err := f(key)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
 	}
+	for key := range m {
+		err := f(key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 type groupedAttrs struct {
@@ -301,6 +513,30 @@ func (ga *groupedAttrs) runOnRecursive(
 	}
 }
 
+// decgroups is the inverse of encgroups, returning the groups and the
+// key.
+func decgroups(encoded string) ([]string, string) {
+	parts := []string{}
+	current := strings.Builder{}
+	escaped := false
+	for _, r := range encoded {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '.':
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	parts = append(parts, current.String())
+	return parts[:len(parts)-1], parts[len(parts)-1]
+}
+
 func dotEncode(s string) string {
 	return strings.ReplaceAll(
 		strings.ReplaceAll(s, "\\", "\\\\"),
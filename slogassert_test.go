@@ -44,24 +44,7 @@ func TestVeryBasicFunctionality(t *testing.T) {
 func TestSlogHandler(t *testing.T) {
 	handler := New(t, slog.LevelDebug, nil)
 	defer handler.AssertEmpty()
-	err := slogtest.TestHandler(handler, func() []map[string]any {
-		results := []map[string]any{}
-
-		for _, lm := range handler.logMessages {
-			handler.logMessages = handler.logMessages[:0]
-
-			result := map[string]any{
-				slog.TimeKey:    lm.Time,
-				slog.LevelKey:   lm.Level,
-				slog.MessageKey: lm.Message,
-			}
-			results = append(results, result)
-		}
-
-		// empty out the results for next time
-		handler.logMessages = []LogMessage{}
-		return results
-	})
+	err := slogtest.TestHandler(handler, slogtestResults(handler))
 	if err != nil {
 		t.Fatalf("incorrect handler behavior: %v", err)
 	}
@@ -258,6 +241,41 @@ func TestWith(t *testing.T) {
 	})
 }
 
+func TestWithTester(t *testing.T) {
+	handler := New(t, slog.LevelInfo, nil)
+	defer handler.AssertEmpty()
+	handler.SetRecordTree(true)
+	log := slog.New(handler.WithAttrs([]slog.Attr{slog.Int("a", 1)}))
+
+	rt := &loggingTester{}
+	view := handler.WithTester(rt)
+	slog.New(view).Info("through view")
+	log.Info("through handler")
+
+	view.AssertMessage("missing")
+	view.AssertEmpty()
+	if len(rt.failures) != 2 ||
+		rt.failures[0] != `No logs with message "missing" found` ||
+		rt.failures[1] != "2 unasserted log message(s); see printout above" {
+		t.Fatalf("incorrect failures: %#v", rt.failures)
+	}
+	// the unasserted messages are reported to the view's Tester
+	if len(rt.logs) != 2 ||
+		!strings.HasPrefix(rt.logs[0], "2 unasserted log message(s):") {
+		t.Fatalf("incorrect logs: %#v", rt.logs)
+	}
+
+	// the view shares the handler's messages
+	view.AssertMessage("through handler")
+	handler.AssertMessage("through view")
+	if len(rt.failures) != 2 {
+		t.Fatalf("incorrect failures: %#v", rt.failures)
+	}
+	if len(handler.Tree().Children) != 1 {
+		t.Fatal("WithTester view appears in the tree")
+	}
+}
+
 type testLogValuer struct{}
 
 func (t testLogValuer) LogValue() slog.Value {
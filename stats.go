@@ -0,0 +1,196 @@
+package slogassert
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Stats holds aggregate statistics about all the records a Handler
+// has handled since statistics were turned on with
+// [Handler.SetStats], whether or not they have since been asserted.
+//
+// BySource is keyed by the "file:line" of the log call, where it is
+// known. ByAttrKey is keyed the same way as LogMessage.Attrs.
+//
+// Bytes is an approximation of the size of the rendered records, for
+// catching log volume regressions; it should not be compared against
+// the output of any particular handler.
+type Stats struct {
+	Total     int
+	Bytes     int
+	ByLevel   map[slog.Level]int
+	ByMessage map[string]int
+	BySource  map[string]int
+	ByAttrKey map[string]int
+}
+
+func newStats() Stats {
+	return Stats{
+		ByLevel:   map[slog.Level]int{},
+		ByMessage: map[string]int{},
+		BySource:  map[string]int{},
+		ByAttrKey: map[string]int{},
+	}
+}
+
+// should be run only under handler lock
+func (s *Stats) add(lm LogMessage, source string) {
+	s.Total++
+	s.Bytes += lm.approxSize()
+	s.ByLevel[lm.Level]++
+	s.ByMessage[lm.Message]++
+	if source != "" {
+		s.BySource[source]++
+	}
+	for key := range lm.Attrs {
+		s.ByAttrKey[key]++
+	}
+}
+
+// approxSize approximates the length of the record's echoLine,
+// without rendering it. Values that are not strings or errors are
+// counted at a typical width.
+func (lm *LogMessage) approxSize() int {
+	size := len(lm.Level.String()) + len(lm.Message) + 3
+	for key, val := range lm.Attrs {
+		size += len(key) + 2
+		switch val.Kind() {
+		case slog.KindString:
+			size += len(val.String())
+		case slog.KindTime:
+			size += len(time.RFC3339)
+		case slog.KindAny:
+			if err, isErr := val.Any().(error); isErr {
+				size += len(err.Error())
+				continue
+			}
+			size += 8
+		default:
+			size += 8
+		}
+	}
+	return size
+}
+
+func (s Stats) clone() Stats {
+	clone := newStats()
+	clone.Total = s.Total
+	clone.Bytes = s.Bytes
+	for level, count := range s.ByLevel {
+		clone.ByLevel[level] = count
+	}
+	for msg, count := range s.ByMessage {
+		clone.ByMessage[msg] = count
+	}
+	for source, count := range s.BySource {
+		clone.BySource[source] = count
+	}
+	for key, count := range s.ByAttrKey {
+		clone.ByAttrKey[key] = count
+	}
+	return clone
+}
+
+// SetStats sets whether the Handler collects [Stats] on the records
+// it handles. This is off by default, as ByMessage and BySource grow
+// with every distinct message and call site. Turning it off discards
+// the statistics collected so far.
+func (h *Handler) SetStats(collect bool) {
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	switch {
+	case !collect:
+		root.stats = nil
+	case root.stats == nil:
+		stats := newStats()
+		root.stats = &stats
+	}
+}
+
+// Stats returns aggregate statistics about all the records the
+// Handler has handled since [Handler.SetStats] turned them on. The
+// result is a copy. If statistics are not being collected, the
+// result is empty.
+func (h *Handler) Stats() Stats {
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	if root.stats == nil {
+		return newStats()
+	}
+	return root.stats.clone()
+}
+
+// requireStats fails the test if statistics are not being collected,
+// returning whether they are.
+func (h *Handler) requireStats() bool {
+	h.t.Helper()
+	root := h.root()
+	root.m.Lock()
+	collect := root.stats != nil
+	root.m.Unlock()
+
+	if !collect {
+		h.t.Fatalf("statistics are not being collected; see SetStats")
+	}
+	return collect
+}
+
+// AssertLevelCounts asserts that exactly the given number of records
+// have been handled at each of the given levels. Levels not in the
+// map are not checked.
+//
+// This is based on [Handler.Stats], and does not consume any
+// messages.
+func (h *Handler) AssertLevelCounts(counts map[slog.Level]int) {
+	h.t.Helper()
+	if !h.requireStats() {
+		return
+	}
+	stats := h.Stats()
+
+	levels := []slog.Level{}
+	for level := range counts {
+		levels = append(levels, level)
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i] < levels[j] })
+
+	wrong := []string{}
+	for _, level := range levels {
+		if stats.ByLevel[level] != counts[level] {
+			wrong = append(wrong, fmt.Sprintf("  %s: expected %d, got %d",
+				level, counts[level], stats.ByLevel[level]))
+		}
+	}
+
+	if len(wrong) > 0 {
+		h.Fail("incorrect number of records by level:\n%s",
+			strings.Join(wrong, "\n"))
+	}
+}
+
+// AssertMessageCardinality asserts that no more than max distinct
+// messages have been logged. This catches messages that have
+// per-record data formatted into them, which should be attributes
+// instead.
+//
+// This is based on [Handler.Stats], and does not consume any
+// messages.
+func (h *Handler) AssertMessageCardinality(max int) {
+	h.t.Helper()
+	if !h.requireStats() {
+		return
+	}
+	stats := h.Stats()
+
+	if len(stats.ByMessage) > max {
+		h.Fail("%d distinct messages logged, expected at most %d",
+			len(stats.ByMessage), max)
+	}
+}
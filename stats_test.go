@@ -0,0 +1,109 @@
+package slogassert
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	handler := New(t, slog.LevelDebug, nil)
+	defer handler.AssertEmpty()
+	handler.SetStats(true)
+	log := slog.New(handler)
+
+	for i := 0; i < 3; i++ {
+		log.Info("loop", "i", i)
+	}
+	log.WithGroup("g").Warn(testWarning, "a", 1)
+	handler.AssertSomeMessage("loop")
+
+	stats := handler.Stats()
+	if stats.Total != 4 ||
+		stats.ByLevel[slog.LevelInfo] != 3 ||
+		stats.ByLevel[slog.LevelWarn] != 1 ||
+		stats.ByMessage["loop"] != 3 ||
+		stats.ByAttrKey["i"] != 3 ||
+		stats.ByAttrKey["g.a"] != 1 ||
+		stats.Bytes == 0 {
+		t.Fatalf("incorrect stats: %#v", stats)
+	}
+	if len(stats.BySource) != 2 {
+		t.Fatalf("incorrect sources: %v", stats.BySource)
+	}
+	for source := range stats.BySource {
+		if !strings.Contains(source, "stats_test.go:") {
+			t.Fatalf("incorrect source: %s", source)
+		}
+	}
+
+	// the result is a copy
+	stats.ByLevel[slog.LevelInfo] = 100
+	if handler.Stats().ByLevel[slog.LevelInfo] != 3 {
+		t.Fatal("Stats did not return a copy")
+	}
+
+	handler.AssertLevelCounts(map[slog.Level]int{
+		slog.LevelInfo:  3,
+		slog.LevelWarn:  1,
+		slog.LevelError: 0,
+	})
+	handler.AssertMessageCardinality(2)
+	handler.AssertMessage(testWarning)
+
+	rt := &recordingTester{}
+	handler.t = rt
+	handler.AssertLevelCounts(map[slog.Level]int{
+		slog.LevelInfo:  1,
+		slog.LevelError: 1,
+	})
+	handler.AssertMessageCardinality(1)
+	handler.t = t
+	if len(rt.failures) != 2 ||
+		!strings.Contains(rt.failures[0], "INFO: expected 1, got 3\n  ERROR: expected 1, got 0") ||
+		!strings.Contains(rt.failures[1], "2 distinct messages logged, expected at most 1") {
+		t.Fatalf("incorrect failures: %v", rt.failures)
+	}
+}
+
+func TestStatsCardinality(t *testing.T) {
+	handler := New(t, slog.LevelDebug, nil)
+	handler.SetStats(true)
+	log := slog.New(handler)
+	for i := 0; i < 3; i++ {
+		log.Info(fmt.Sprintf("iteration %d", i))
+	}
+	handler.Reset()
+
+	rt := &recordingTester{}
+	handler.t = rt
+	handler.AssertMessageCardinality(1)
+	if len(rt.failures) != 1 {
+		t.Fatal("message cardinality not enforced")
+	}
+}
+
+func TestStatsOff(t *testing.T) {
+	rt := &recordingTester{}
+	handler := New(rt, slog.LevelDebug, nil)
+	slog.New(handler).Info("before")
+	if handler.Stats().Total != 0 {
+		t.Fatal("stats collected without SetStats")
+	}
+	handler.AssertMessageCardinality(1)
+	if len(rt.failures) != 1 ||
+		!strings.Contains(rt.failures[0], "SetStats") {
+		t.Fatalf("incorrect failures: %v", rt.failures)
+	}
+
+	handler.SetStats(true)
+	slog.New(handler).Info("after")
+	if handler.Stats().Total != 1 {
+		t.Fatal("stats not collected")
+	}
+	handler.SetStats(false)
+	if handler.Stats().Total != 0 {
+		t.Fatal("stats not discarded")
+	}
+}
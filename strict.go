@@ -0,0 +1,50 @@
+package slogassert
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+)
+
+// SetStrict puts the Handler into strict mode, in which any record at
+// or above the given level that is not covered by an ignore rule (see
+// [Handler.Ignore]) or an expectation (see [Handler.Expect]) fails
+// the test immediately, from within the Handle call, rather than
+// waiting for AssertEmpty to discover it.
+//
+// The failure is reported with Errorf if the Tester has it, as
+// *testing.T does, since it will usually be called from a goroutine
+// other than the test's. It includes the call site of the log call
+// and its stack trace. Such records are not stored.
+//
+// Passing nil turns strict mode off.
+func (h *Handler) SetStrict(leveler slog.Leveler) {
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	root.strict = leveler
+}
+
+// should be run only under handler lock
+func (h *Handler) strictFailure(lm LogMessage) string {
+	if h.strict == nil || lm.Level < h.strict.Level() {
+		return ""
+	}
+	return fmt.Sprintf("unexpected log message %q at level %s in strict mode",
+		lm.Message, lm.Level)
+}
+
+// callSite returns the file and line of the log call that created a
+// record with the given PC, or "" if it is unknown.
+func callSite(pc uintptr) string {
+	if pc == 0 {
+		return ""
+	}
+	frames := runtime.CallersFrames([]uintptr{pc})
+	frame, _ := frames.Next()
+	if frame.File == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+}
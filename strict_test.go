@@ -0,0 +1,61 @@
+package slogassert
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestStrict(t *testing.T) {
+	et := &erroringTester{}
+	handler := New(et, slog.LevelInfo, nil)
+	handler.SetStrict(slog.LevelError)
+	handler.Ignore(LogMessageMatch{Message: "ignored", Level: LevelDontCare})
+	handler.Expect(LogMessageMatch{Message: "expected", Level: LevelDontCare})
+	log := slog.New(handler)
+
+	log.Warn(testWarning)
+	log.Error("ignored")
+	log.Error("expected")
+	if len(et.errors) != 0 {
+		t.Fatalf("unexpected strict failures: %v", et.errors)
+	}
+
+	log.Error("unexpected")
+	if len(et.errors) != 1 ||
+		!strings.Contains(et.errors[0],
+			`unexpected log message "unexpected" at level ERROR in strict mode`) ||
+		!strings.Contains(et.errors[0], "logged at ") ||
+		!strings.Contains(et.errors[0], "strict_test.go:") {
+		t.Fatalf("incorrect strict failure: %v", et.errors)
+	}
+
+	// the failing record is not stored
+	handler.AssertMessage(testWarning)
+	handler.AssertEmpty()
+
+	handler.SetStrict(nil)
+	log.Error("unexpected")
+	if len(et.errors) != 1 {
+		t.Fatal("strict mode not turned off")
+	}
+	handler.AssertMessage("unexpected")
+	handler.AssertExpectations()
+	if len(et.failures) != 0 {
+		t.Fatalf("unexpected failures: %v", et.failures)
+	}
+}
+
+func TestDefaultStrict(t *testing.T) {
+	handler := NewDefault(t, WithStrict(slog.LevelError))
+	handler.Expect(LogMessageMatch{Message: "expected", Level: LevelDontCare})
+	slog.Error("expected")
+	slog.Warn(testWarning)
+	handler.AssertMessage(testWarning)
+}
+
+func TestCallSite(t *testing.T) {
+	if callSite(0) != "" {
+		t.Fatal("incorrect call site for unknown PC")
+	}
+}
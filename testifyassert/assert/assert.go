@@ -0,0 +1,148 @@
+/*
+Package assert provides testify-style assertions on a
+[slogassert.Handler], which report failures through testify's
+assert.Fail and return whether they passed, rather than calling the
+Handler's Tester:
+
+	assert.Logged(t, handler, slogassert.Msg("started"))
+	assert.LoggedExactly(t, handler, 3, slogassert.Msg("retrying"))
+	assert.Empty(t, handler)
+
+Each assertion asserts log messages exactly as the corresponding
+Handler method does. See the sibling require package for versions
+that stop the test on failure.
+
+This is a separate module so that slogassert itself does not depend
+on testify.
+*/
+package assert
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thejerf/slogassert"
+)
+
+// tester is a slogassert.Tester that reports failures through
+// testify's assert.Fail.
+//
+// It has an Output method, so that the log messages slogassert
+// prints to explain a failure are collected into report, and
+// included in the failure, rather than going to os.Stderr.
+type tester struct {
+	t          assert.TestingT
+	msgAndArgs []any
+	failed     bool
+	report     strings.Builder
+}
+
+func (tt *tester) Output() io.Writer {
+	return &tt.report
+}
+
+func (tt *tester) Helper() {
+	h, isHelper := tt.t.(interface{ Helper() })
+	if isHelper {
+		h.Helper()
+	}
+}
+
+func (tt *tester) Fatalf(msg string, args ...any) {
+	tt.Helper()
+	if tt.failed {
+		// only report the first failure of an assertion
+		return
+	}
+	tt.failed = true
+	failure := fmt.Sprintf(msg, args...)
+	if tt.report.Len() > 0 {
+		failure += "\n" + tt.report.String()
+		tt.report.Reset()
+	}
+	assert.Fail(tt.t, failure, tt.msgAndArgs...)
+}
+
+// check runs the assertion against a view of the handler that reports
+// through testify, returning whether it passed.
+func check(
+	t assert.TestingT,
+	handler *slogassert.Handler,
+	msgAndArgs []any,
+	assertion func(*slogassert.Handler),
+) bool {
+	tt := &tester{t: t, msgAndArgs: msgAndArgs}
+	tt.Helper()
+	assertion(handler.WithTester(tt))
+
+	// anything reported without a failure, such as the messages an
+	// EmptyPolicy only reports, is logged if the TestingT can
+	l, isLogfer := t.(interface{ Logf(string, ...any) })
+	if tt.report.Len() > 0 && isLogfer {
+		l.Logf("%s", tt.report.String())
+	}
+	return !tt.failed
+}
+
+// Logged asserts the first unasserted log message matching the
+// LogMessageMatch, as Handler.AssertPrecise does.
+func Logged(
+	t assert.TestingT,
+	handler *slogassert.Handler,
+	lmm slogassert.LogMessageMatch,
+	msgAndArgs ...any,
+) bool {
+	if h, isHelper := t.(interface{ Helper() }); isHelper {
+		h.Helper()
+	}
+	return check(t, handler, msgAndArgs, func(h *slogassert.Handler) {
+		h.AssertPrecise(lmm)
+	})
+}
+
+// LoggedExactly asserts all the unasserted log messages matching the
+// LogMessageMatch, and that there are exactly n of them, as
+// Handler.AssertCount does.
+func LoggedExactly(
+	t assert.TestingT,
+	handler *slogassert.Handler,
+	n int,
+	lmm slogassert.LogMessageMatch,
+	msgAndArgs ...any,
+) bool {
+	if h, isHelper := t.(interface{ Helper() }); isHelper {
+		h.Helper()
+	}
+	return check(t, handler, msgAndArgs, func(h *slogassert.Handler) {
+		h.AssertCount(lmm.Exactly(n))
+	})
+}
+
+// LoggedMessage asserts the first unasserted log message with the
+// given message, as Handler.AssertMessage does.
+func LoggedMessage(
+	t assert.TestingT,
+	handler *slogassert.Handler,
+	msg string,
+	msgAndArgs ...any,
+) bool {
+	if h, isHelper := t.(interface{ Helper() }); isHelper {
+		h.Helper()
+	}
+	return check(t, handler, msgAndArgs, func(h *slogassert.Handler) {
+		h.AssertMessage(msg)
+	})
+}
+
+// Empty asserts that all log messages have been accounted for, as
+// Handler.AssertEmpty does.
+func Empty(t assert.TestingT, handler *slogassert.Handler, msgAndArgs ...any) bool {
+	if h, isHelper := t.(interface{ Helper() }); isHelper {
+		h.Helper()
+	}
+	return check(t, handler, msgAndArgs, func(h *slogassert.Handler) {
+		h.AssertEmpty()
+	})
+}
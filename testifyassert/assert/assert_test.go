@@ -0,0 +1,110 @@
+package assert
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/thejerf/slogassert"
+)
+
+// erroringT is a testify TestingT that records errors.
+type erroringT struct {
+	errors []string
+}
+
+func (et *erroringT) Errorf(msg string, args ...any) {
+	et.errors = append(et.errors, fmt.Sprintf(msg, args...))
+}
+
+func TestAssertions(t *testing.T) {
+	handler := slogassert.New(t, slog.LevelInfo, nil)
+	log := slog.New(handler)
+
+	log.Info("started", "port", 80)
+	log.Info("retrying")
+	log.Info("retrying")
+	log.Info("stopped")
+
+	if !Logged(t, handler, slogassert.Msg("started").Attr("port", 80)) ||
+		!LoggedExactly(t, handler, 2, slogassert.Msg("retrying")) ||
+		!LoggedMessage(t, handler, "stopped") ||
+		!Empty(t, handler) {
+		t.Fatal("passing assertions failed")
+	}
+}
+
+func TestFailures(t *testing.T) {
+	handler := slogassert.New(t, slog.LevelInfo, nil)
+	defer handler.AssertEmpty()
+	log := slog.New(handler)
+	et := &erroringT{}
+
+	log.Info("retrying", "count", 1)
+
+	if Logged(et, handler, slogassert.Msg("started"), "starting %s", "server") ||
+		Logged(et, handler, slogassert.Msg("retrying").Attr("count", func(int) bool { return true })) ||
+		LoggedExactly(et, handler, 2, slogassert.Msg("retrying")) ||
+		LoggedMessage(et, handler, "stopped") {
+		t.Fatal("failing assertions passed")
+	}
+	log.Info("stopped")
+	if Empty(et, handler) {
+		t.Fatal("Empty passed with an unasserted message")
+	}
+	handler.AssertMessage("stopped")
+
+	if len(et.errors) != 5 {
+		t.Fatalf("incorrect number of failures: %#v", et.errors)
+	}
+	for idx, expected := range []string{
+		"No logs matching filter were found",
+		"cannot compare any slog.Kind",
+		`Expected logs matching message "retrying" exactly 2 time(s), found 1`,
+		`No logs with message "stopped" found`,
+		"1 unasserted log message(s)",
+	} {
+		if !strings.Contains(et.errors[idx], expected) {
+			t.Fatalf("failure %d does not contain %q: %s", idx, expected, et.errors[idx])
+		}
+	}
+	if !strings.Contains(et.errors[0], "starting server") {
+		t.Fatalf("msgAndArgs not reported: %s", et.errors[0])
+	}
+}
+
+func TestFailureReport(t *testing.T) {
+	stderr, err := os.CreateTemp(t.TempDir(), "stderr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	origStderr := os.Stderr
+	os.Stderr = stderr
+	defer func() { os.Stderr = origStderr }()
+
+	handler := slogassert.New(t, slog.LevelInfo, nil)
+	defer handler.AssertEmpty()
+	et := &erroringT{}
+	slog.New(handler).Info("unexpected", "count", 1)
+
+	if Logged(et, handler, slogassert.Msg("started")) {
+		t.Fatal("failing assertion passed")
+	}
+	handler.AssertMessage("unexpected")
+
+	os.Stderr = origStderr
+	written, err := os.ReadFile(stderr.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(written) != 0 {
+		t.Fatalf("failure report written to stderr: %s", written)
+	}
+	if len(et.errors) != 1 ||
+		!strings.Contains(et.errors[0], "1 unasserted log message(s):") ||
+		!strings.Contains(et.errors[0], "unexpected") {
+		t.Fatalf("failure does not include the report: %#v", et.errors)
+	}
+}
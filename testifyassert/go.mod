@@ -0,0 +1,16 @@
+module github.com/thejerf/slogassert/testifyassert
+
+go 1.21
+
+require (
+	github.com/stretchr/testify v1.9.0
+	github.com/thejerf/slogassert v0.0.0-00010101000000-000000000000
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/thejerf/slogassert => ../
@@ -0,0 +1,69 @@
+// Package require provides the same assertions on a
+// [slogassert.Handler] as the sibling assert package, except that
+// they stop the test with t.FailNow on failure, as testify's require
+// package does.
+package require
+
+import (
+	"github.com/stretchr/testify/require"
+	"github.com/thejerf/slogassert"
+	"github.com/thejerf/slogassert/testifyassert/assert"
+)
+
+// Logged is assert.Logged, stopping the test if it fails.
+func Logged(
+	t require.TestingT,
+	handler *slogassert.Handler,
+	lmm slogassert.LogMessageMatch,
+	msgAndArgs ...any,
+) {
+	if h, isHelper := t.(interface{ Helper() }); isHelper {
+		h.Helper()
+	}
+	if !assert.Logged(t, handler, lmm, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// LoggedExactly is assert.LoggedExactly, stopping the test if it
+// fails.
+func LoggedExactly(
+	t require.TestingT,
+	handler *slogassert.Handler,
+	n int,
+	lmm slogassert.LogMessageMatch,
+	msgAndArgs ...any,
+) {
+	if h, isHelper := t.(interface{ Helper() }); isHelper {
+		h.Helper()
+	}
+	if !assert.LoggedExactly(t, handler, n, lmm, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// LoggedMessage is assert.LoggedMessage, stopping the test if it
+// fails.
+func LoggedMessage(
+	t require.TestingT,
+	handler *slogassert.Handler,
+	msg string,
+	msgAndArgs ...any,
+) {
+	if h, isHelper := t.(interface{ Helper() }); isHelper {
+		h.Helper()
+	}
+	if !assert.LoggedMessage(t, handler, msg, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// Empty is assert.Empty, stopping the test if it fails.
+func Empty(t require.TestingT, handler *slogassert.Handler, msgAndArgs ...any) {
+	if h, isHelper := t.(interface{ Helper() }); isHelper {
+		h.Helper()
+	}
+	if !assert.Empty(t, handler, msgAndArgs...) {
+		t.FailNow()
+	}
+}
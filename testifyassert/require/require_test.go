@@ -0,0 +1,61 @@
+package require
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/thejerf/slogassert"
+)
+
+// stoppingT is a testify require.TestingT that records errors and
+// whether the test was stopped.
+type stoppingT struct {
+	errors  []string
+	stopped bool
+}
+
+func (st *stoppingT) Errorf(msg string, args ...any) {
+	st.errors = append(st.errors, fmt.Sprintf(msg, args...))
+}
+
+func (st *stoppingT) FailNow() {
+	st.stopped = true
+}
+
+func TestRequire(t *testing.T) {
+	handler := slogassert.New(t, slog.LevelInfo, nil)
+	defer handler.AssertEmpty()
+	log := slog.New(handler)
+
+	log.Info("started")
+	log.Info("retrying")
+
+	st := &stoppingT{}
+	Logged(st, handler, slogassert.Msg("started"))
+	LoggedExactly(st, handler, 1, slogassert.Msg("retrying"))
+	Empty(st, handler)
+	if st.stopped || len(st.errors) != 0 {
+		t.Fatalf("passing assertions failed: %#v", st.errors)
+	}
+
+	for _, assertion := range []func(){
+		func() { Logged(st, handler, slogassert.Msg("started")) },
+		func() { LoggedExactly(st, handler, 1, slogassert.Msg("retrying")) },
+		func() { LoggedMessage(st, handler, "stopped") },
+		func() {
+			log.Info("stopped")
+			Empty(st, handler)
+			handler.AssertMessage("stopped")
+		},
+	} {
+		st.stopped = false
+		assertion()
+		if !st.stopped {
+			t.Fatal("failing assertion did not stop the test")
+		}
+	}
+	if len(st.errors) != 4 {
+		t.Fatalf("incorrect failures: %#v", st.errors)
+	}
+}
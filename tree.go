@@ -0,0 +1,235 @@
+package slogassert
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+// A HandlerNode describes a Handler in the tree of Handlers derived
+// from a root Handler through WithAttrs and WithGroup.
+//
+// Groups is the current group of the Handler, which will be applied
+// to any further attributes.
+//
+// Attrs are all of the attributes that have been added to the Handler
+// by WithAttrs calls on it or any of its ancestors, keyed the same way
+// as LogMessage.Attrs. LogValuers are not resolved.
+//
+// Handled is the number of records handled directly by this Handler,
+// not counting those handled by its children.
+type HandlerNode struct {
+	Groups   []string
+	Attrs    map[string]slog.Value
+	Handled  int
+	Children []HandlerNode
+}
+
+// Walk calls f on this node and all of its descendants, depth-first,
+// stopping if f returns false.
+func (hn HandlerNode) Walk(f func(HandlerNode) bool) bool {
+	if !f(hn) {
+		return false
+	}
+	for _, child := range hn.Children {
+		if !child.Walk(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// totalHandled returns the number of records handled by the node and
+// all of its descendants.
+func (hn HandlerNode) totalHandled() int {
+	total := hn.Handled
+	for _, child := range hn.Children {
+		total += child.totalHandled()
+	}
+	return total
+}
+
+// SetRecordTree sets whether the root Handler records the Handlers
+// derived from it through WithAttrs and WithGroup, for [Handler.Tree],
+// [Handler.AssertChildCreated], and [Handler.AssertNoUnusedChildren].
+// Only Handlers derived while this is on are recorded.
+//
+// This is off by default, because the root Handler has to keep every
+// derived Handler for its lifetime in order to provide it, which
+// is a leak for code that derives a logger for every request.
+// Turning it off forgets the Handlers recorded so far.
+func (h *Handler) SetRecordTree(record bool) {
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	root.recordTree = record
+	if !record {
+		root.children = nil
+	}
+}
+
+// Tree returns the tree of Handlers derived from the root Handler via
+// WithAttrs and WithGroup, including the root itself. This can be
+// used to examine how code derived its loggers, without the code
+// having to log anything.
+//
+// Derived Handlers are only included if they were created while
+// recording was turned on with [Handler.SetRecordTree].
+func (h *Handler) Tree() HandlerNode {
+	root := h.root()
+	root.m.Lock()
+	defer root.m.Unlock()
+
+	return root.node()
+}
+
+// should be run only under handler lock
+func (h *Handler) node() HandlerNode {
+	hn := HandlerNode{
+		Groups:  append([]string{}, h.currentGroup...),
+		Attrs:   map[string]slog.Value{},
+		Handled: h.handled,
+	}
+	h.attrs.runOn(func(group []string, attr slog.Attr) bool {
+		flattenAttr(hn.Attrs, group, attr)
+		return true
+	})
+	for _, child := range h.children {
+		hn.Children = append(hn.Children, child.node())
+	}
+	return hn
+}
+
+// flattenAttr records the attribute into the attrs map, flattening
+// groups, without resolving LogValuers.
+func flattenAttr(attrs map[string]slog.Value, group []string, attr slog.Attr) {
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+	if attr.Value.Kind() != slog.KindGroup {
+		attrs[encgroups(group, attr.Key)] = attr.Value
+		return
+	}
+
+	newGroups := group
+	if attr.Key != "" {
+		newGroups = append(append([]string{}, group...), attr.Key)
+	}
+	for _, sub := range attr.Value.Group() {
+		flattenAttr(attrs, newGroups, sub)
+	}
+}
+
+// AssertChildCreated asserts that some Handler was derived from the
+// root Handler that has the given current group, and whose attributes
+// match the given attrs. The attrs are keyed and matched the same way
+// as LogMessageMatch.Attrs, except that the derived Handler may have
+// additional attributes.
+//
+// For instance, code that does
+//
+//	logger.WithGroup("db").With("component", "pool")
+//
+// can be checked with
+//
+//	handler.AssertChildCreated([]string{"db"}, map[string]any{
+//		"db.component": "pool",
+//	})
+//
+// This does not consume anything, and may be called repeatedly.
+func (h *Handler) AssertChildCreated(groups []string, attrs map[string]any) {
+	h.t.Helper()
+	if !h.requireRecordTree() {
+		return
+	}
+
+	found := false
+	// the root itself was not derived, so only its children count
+	for _, child := range h.Tree().Children {
+		if !child.Walk(func(hn HandlerNode) bool {
+			found = hn.matches(groups, attrs)
+			return !found
+		}) {
+			break
+		}
+	}
+
+	if !found {
+		h.Fail("No handler derived with groups %v and attrs %v", groups, attrs)
+	}
+}
+
+// requireRecordTree fails the test if the tree is not being
+// recorded, as the tree assertions would otherwise pass vacuously,
+// returning whether it is.
+func (h *Handler) requireRecordTree() bool {
+	h.t.Helper()
+	root := h.root()
+	root.m.Lock()
+	record := root.recordTree
+	root.m.Unlock()
+
+	if !record {
+		h.t.Fatalf("the tree of derived handlers is not being recorded; see SetRecordTree")
+	}
+	return record
+}
+
+func (hn HandlerNode) matches(groups []string, attrs map[string]any) bool {
+	if len(groups) != len(hn.Groups) {
+		return false
+	}
+	if len(groups) != 0 && !reflect.DeepEqual(groups, hn.Groups) {
+		return false
+	}
+	for key, matcher := range attrs {
+		val, haveVal := hn.Attrs[key]
+		if !haveVal {
+			return false
+		}
+		if matchAttr(matcher, val) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// AssertNoUnusedChildren asserts that every Handler derived from the
+// root Handler through WithAttrs or WithGroup was used to log
+// something, either directly or through a Handler derived from it in
+// turn. This can find loggers that were set up and then never used,
+// which usually means the wrong logger is being used somewhere.
+func (h *Handler) AssertNoUnusedChildren() {
+	h.t.Helper()
+	if !h.requireRecordTree() {
+		return
+	}
+
+	unused := []string{}
+	tree := h.Tree()
+	for _, child := range tree.Children {
+		child.Walk(func(hn HandlerNode) bool {
+			if hn.totalHandled() == 0 {
+				unused = append(unused, hn.describe())
+			}
+			return true
+		})
+	}
+
+	if len(unused) > 0 {
+		h.Fail("%d derived handler(s) never logged anything:\n%s",
+			len(unused), strings.Join(unused, "\n"))
+	}
+}
+
+func (hn HandlerNode) describe() string {
+	keys := sortedKeys(hn.Attrs)
+	attrs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		attrs = append(attrs, fmt.Sprintf("%s=%v", key, hn.Attrs[key].Any()))
+	}
+	return fmt.Sprintf("  groups: %v attrs: [%s]",
+		hn.Groups, strings.Join(attrs, " "))
+}
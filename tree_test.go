@@ -0,0 +1,104 @@
+package slogassert
+
+import (
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTree(t *testing.T) {
+	handler := New(t, slog.LevelWarn, nil)
+	defer handler.AssertEmpty()
+	handler.SetRecordTree(true)
+	log := slog.New(handler)
+
+	db := log.WithGroup("db").With("component", "pool")
+	web := log.With("component", "web", slog.Group("g", "inner", 1))
+	web.Warn(testWarning)
+	handler.AssertMessage(testWarning)
+
+	tree := handler.Tree()
+	if len(tree.Children) != 2 || tree.Handled != 0 {
+		t.Fatalf("incorrect tree: %#v", tree)
+	}
+	dbGroup := tree.Children[0]
+	if !reflect.DeepEqual(dbGroup.Groups, []string{"db"}) ||
+		len(dbGroup.Attrs) != 0 || len(dbGroup.Children) != 1 {
+		t.Fatalf("incorrect db group node: %#v", dbGroup)
+	}
+	dbAttrs := dbGroup.Children[0]
+	if dbAttrs.Attrs["db.component"].String() != "pool" {
+		t.Fatalf("incorrect db attrs node: %#v", dbAttrs)
+	}
+	webNode := tree.Children[1]
+	if webNode.Handled != 1 ||
+		webNode.Attrs["component"].String() != "web" ||
+		webNode.Attrs["g.inner"].Int64() != 1 {
+		t.Fatalf("incorrect web node: %#v", webNode)
+	}
+
+	handler.AssertChildCreated([]string{"db"}, map[string]any{
+		"db.component": "pool",
+	})
+	handler.AssertChildCreated(nil, map[string]any{"component": "web"})
+	handler.AssertChildCreated(nil, nil)
+
+	rt := &recordingTester{}
+	failing := New(rt, slog.LevelWarn, nil)
+	failing.SetRecordTree(true)
+	failing.WithGroup("db")
+	failing.AssertChildCreated([]string{"db"}, map[string]any{
+		"db.component": "pool",
+	})
+	failing.AssertChildCreated([]string{"web"}, nil)
+	// the root is not a derived handler
+	childless := New(rt, slog.LevelWarn, nil)
+	childless.SetRecordTree(true)
+	childless.AssertChildCreated(nil, nil)
+	if len(rt.failures) != 3 ||
+		!strings.HasPrefix(rt.failures[2], "No handler derived") {
+		t.Fatalf("incorrect failures: %v", rt.failures)
+	}
+
+	rt = &recordingTester{}
+	handler.t = rt
+	handler.AssertNoUnusedChildren()
+	if len(rt.failures) != 1 ||
+		!strings.Contains(rt.failures[0], "2 derived handler(s)") ||
+		!strings.Contains(rt.failures[0], "db.component=pool") {
+		t.Fatalf("incorrect unused children failure: %v", rt.failures)
+	}
+	handler.t = t
+
+	db.Warn(testWarning)
+	handler.AssertMessage(testWarning)
+	handler.AssertNoUnusedChildren()
+}
+
+func TestTreeNotRecorded(t *testing.T) {
+	rt := &recordingTester{}
+	handler := New(rt, slog.LevelWarn, nil)
+	slog.New(handler).With("component", "web")
+
+	if len(handler.Tree().Children) != 0 {
+		t.Fatal("tree recorded without SetRecordTree")
+	}
+	handler.AssertNoUnusedChildren()
+	handler.AssertChildCreated(nil, nil)
+	if len(rt.failures) != 2 ||
+		!strings.Contains(rt.failures[0], "SetRecordTree") ||
+		!strings.Contains(rt.failures[1], "SetRecordTree") {
+		t.Fatalf("incorrect failures: %v", rt.failures)
+	}
+
+	handler.SetRecordTree(true)
+	slog.New(handler).With("component", "web")
+	if len(handler.Tree().Children) != 1 {
+		t.Fatal("tree not recorded")
+	}
+	handler.SetRecordTree(false)
+	if len(handler.Tree().Children) != 0 {
+		t.Fatal("tree not forgotten")
+	}
+}
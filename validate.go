@@ -0,0 +1,139 @@
+package slogassert
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"time"
+)
+
+// An InvalidMatcherError is returned by [LogMessageMatch.Validate] and
+// [LogMessageMatch.MatchError] when an attribute matcher can not be
+// used to match, as opposed to simply not matching. This is almost
+// always a bug in the test, such as using "5" to match an int
+// attribute, or a func(int) bool where a func(int64) bool is needed.
+//
+// Context is true if the matcher was for a value in the
+// LogMessageMatch's Context rather than its Attrs.
+type InvalidMatcherError struct {
+	Key     string
+	Context bool
+	Err     error
+}
+
+func (ime *InvalidMatcherError) Error() string {
+	if ime.Context {
+		return fmt.Sprintf("context `%s`: %s", ime.Key, ime.Err)
+	}
+	return fmt.Sprintf("attr `%s`: %s", ime.Key, ime.Err)
+}
+
+func (ime *InvalidMatcherError) Unwrap() error {
+	return ime.Err
+}
+
+// matcherFuncs are the function types matchAttr knows how to call.
+var matcherFuncs = map[reflect.Type]bool{
+	reflect.TypeOf((func(slog.Value) bool)(nil)):    true,
+	reflect.TypeOf((func(any) bool)(nil)):           true,
+	reflect.TypeOf((func(bool) bool)(nil)):          true,
+	reflect.TypeOf((func(time.Duration) bool)(nil)): true,
+	reflect.TypeOf((func(float64) bool)(nil)):       true,
+	reflect.TypeOf((func(int64) bool)(nil)):         true,
+	reflect.TypeOf((func(string) bool)(nil)):        true,
+	reflect.TypeOf((func(time.Time) bool)(nil)):     true,
+	reflect.TypeOf((func(uint64) bool)(nil)):        true,
+}
+
+// isFuncMatcher returns true if the matcher is a non-nil function.
+func isFuncMatcher(matcher any) bool {
+	v := reflect.ValueOf(matcher)
+	return v.Kind() == reflect.Func && !v.IsNil()
+}
+
+// Validate checks the Attrs matchers for problems that can be
+// detected without any log message, such as a func(int) bool, which
+// can never be called since no slog.Kind holds an int. It returns an
+// *InvalidMatcherError for the first such matcher, by sorted key.
+//
+// Matchers that are only invalid for some Kinds, such as a string
+// used to match a KindInt64, can only be detected by
+// [LogMessageMatch.MatchError].
+func (lmm LogMessageMatch) Validate() error {
+	err := validateAttrs("", lmm.Attrs)
+	if err != nil {
+		return err
+	}
+	for _, name := range sortedKeys(lmm.Context) {
+		matcher := lmm.Context[name]
+		_, isValueMatcher := matcher.(ValueMatcher)
+		if !isValueMatcher && isFuncMatcher(matcher) &&
+			!matcherFuncs[reflect.TypeOf(matcher)] {
+			return &InvalidMatcherError{
+				Key:     name,
+				Context: true,
+				Err:     fmt.Errorf("matcher of type %T cannot compare any slog.Kind", matcher),
+			}
+		}
+	}
+	return nil
+}
+
+// MatchError is [LogMessageMatch.Matches], except that it explains
+// the result. It returns nil if the LogMessage matches. If an
+// attribute matcher can not compare the attribute's value, it returns
+// an *InvalidMatcherError. Otherwise it returns an error describing
+// the first reason the message does not match.
+func (lmm LogMessageMatch) MatchError(lm LogMessage) error {
+	if lmm.Message != lm.Message {
+		return &mismatch{"", fmt.Sprintf("message %q is not %q", lm.Message, lmm.Message)}
+	}
+	if lmm.Level != LevelDontCare && lmm.Level != lm.Level {
+		return &mismatch{"", fmt.Sprintf("level %s is not %s", lm.Level, lmm.Level)}
+	}
+	return lmm.matchValues(lm, true)
+}
+
+// matchValues matches the LogMessage's attributes and context. See
+// matchAttrs for explain.
+func (lmm LogMessageMatch) matchValues(lm LogMessage, explain bool) error {
+	err := matchAttrs("", lmm.Attrs, lmm.AllAttrsMatch, lm.Attrs,
+		lmm.StrictKinds, explain)
+	if err != nil {
+		return err
+	}
+	return matchContext(lmm.Context, lm.Context, lmm.StrictKinds, explain)
+}
+
+// checkedMatch returns a function that matches log messages against
+// the LogMessageMatch for use with Assert, storing the first
+// *InvalidMatcherError it encounters in invalid, so the assertion can
+// report it rather than just failing to find a match.
+func checkedMatch(lmm LogMessageMatch, invalid *error) func(LogMessage) bool {
+	return func(lm LogMessage) bool {
+		if lmm.Matches(lm) {
+			return true
+		}
+		if !lmm.matchesHeader(lm) {
+			return false
+		}
+		// explain the failure to find any invalid matcher
+		// deterministically
+		err := lmm.MatchError(lm)
+		var ime *InvalidMatcherError
+		if errors.As(err, &ime) && *invalid == nil {
+			*invalid = err
+		}
+		return err == nil
+	}
+}
+
+// validate fails the test if the LogMessageMatch is not valid.
+func (h *Handler) validate(lmm LogMessageMatch) {
+	h.t.Helper()
+	err := lmm.Validate()
+	if err != nil {
+		h.t.Fatalf("invalid LogMessageMatch %s: %s", lmm, err)
+	}
+}
@@ -0,0 +1,152 @@
+package slogassert
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	valid := Msg("x").
+		Attr("a", func(int64) bool { return true }).
+		Attr("b", func(slog.Value) bool { return true }).
+		Attr("c", "5").
+		Attr("d", Fields(map[string]any{})).
+		Attr("e", ValueAsString(1)).
+		Attr("f", nil)
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("valid match failed validation: %v", err)
+	}
+
+	err := valid.Attr("count", func(int) bool { return true }).Validate()
+	var ime *InvalidMatcherError
+	if !errors.As(err, &ime) || ime.Key != "count" ||
+		err.Error() != "attr `count`: matcher of type func(int) bool cannot compare any slog.Kind" {
+		t.Fatalf("incorrect validation error: %v", err)
+	}
+}
+
+func TestMatchError(t *testing.T) {
+	lm := LogMessage{
+		Message: "processed",
+		Level:   slog.LevelInfo,
+		Attrs: map[string]slog.Value{
+			"count": slog.Int64Value(5),
+			"user":  slog.AnyValue(struct{ Name string }{"bob"}),
+		},
+	}
+
+	for _, test := range []struct {
+		lmm    LogMessageMatch
+		reason string
+	}{
+		{Msg("processed").Attr("count", 5), ""},
+		{Msg("other"), `message "processed" is not "other"`},
+		{Msg("processed").AtLevel(slog.LevelWarn), "level INFO is not WARN"},
+		{Msg("processed").Attr("missing", 1), "attr `missing` is missing"},
+		{Msg("processed").Attr("count", 6), "attr `count`: does not match"},
+		{
+			Msg("processed").Attr("user", Fields(map[string]any{"Name": "alice"})),
+			"attr `user`: .Name: got \"bob\"",
+		},
+		{Msg("processed").Attr("count", 5).AllAttrs(), "attr `user` is not matched"},
+	} {
+		err := test.lmm.MatchError(lm)
+		if test.reason == "" {
+			if err != nil || !test.lmm.Matches(lm) {
+				t.Fatalf("%s should match: %v", test.lmm, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != test.reason || !errors.Is(err, errNoMatch) {
+			t.Fatalf("%s: incorrect mismatch: %v", test.lmm, err)
+		}
+		if test.lmm.Matches(lm) {
+			t.Fatalf("%s matches despite a mismatch", test.lmm)
+		}
+	}
+
+	for _, test := range []struct {
+		matcher any
+		reason  string
+	}{
+		{"5", "attr `count`: matcher of type string cannot compare KindInt64"},
+		{
+			func(int) bool { return true },
+			"attr `count`: matcher of type func(int) bool cannot compare KindInt64",
+		},
+	} {
+		err := Msg("processed").Attr("count", test.matcher).MatchError(lm)
+		var ime *InvalidMatcherError
+		if !errors.As(err, &ime) || err.Error() != test.reason {
+			t.Fatalf("incorrect invalid matcher error: %v", err)
+		}
+	}
+
+	err := Msg("processed").
+		Attr("user", func(struct{ Name string }) bool { return true }).
+		MatchError(lm)
+	if err == nil || errors.Is(err, errNoMatch) {
+		t.Fatalf("func matcher against KindAny was not invalid: %v", err)
+	}
+}
+
+func TestMatchesDoesNotAllocate(t *testing.T) {
+	lm := LogMessage{
+		Message: "processed",
+		Level:   slog.LevelInfo,
+		Attrs: map[string]slog.Value{
+			"count": slog.Int64Value(5),
+			"name":  slog.StringValue("bob"),
+		},
+	}
+
+	for _, lmm := range []LogMessageMatch{
+		Msg("other"),
+		Msg("processed").AtLevel(slog.LevelWarn),
+		Msg("processed").Attr("missing", 1),
+		Msg("processed").Attr("count", 6).Attr("name", "bob"),
+		Msg("processed").Attr("count", 5).AllAttrs(),
+	} {
+		allocs := testing.AllocsPerRun(10, func() {
+			if lmm.Matches(lm) {
+				t.Fatalf("%s matches", lmm)
+			}
+		})
+		if allocs != 0 {
+			t.Fatalf("%s: Matches allocated %v times", lmm, allocs)
+		}
+	}
+}
+
+func TestInvalidMatcherAssertions(t *testing.T) {
+	handler := New(t, slog.LevelInfo, nil)
+	defer handler.AssertEmpty()
+	log := slog.New(handler)
+
+	rt := &recordingTester{}
+	handler.t = rt
+	log.Info("processed", "count", 5)
+	handler.AssertPrecise(Msg("processed").Attr("count", "5"))
+	handler.AssertSomePrecise(Msg("processed").Attr("count", "5"))
+	handler.AssertCount(Msg("processed").Attr("count", "5").Exactly(1))
+	handler.AssertPrecise(Msg("processed").Attr("count", func(int) bool { return true }))
+	handler.t = t
+
+	if len(rt.failures) != 5 {
+		t.Fatalf("incorrect failures: %#v", rt.failures)
+	}
+	for idx := 0; idx < 3; idx++ {
+		if !strings.HasSuffix(rt.failures[idx],
+			"attr `count`: matcher of type string cannot compare KindInt64") {
+			t.Fatalf("invalid matcher not reported: %s", rt.failures[idx])
+		}
+	}
+	if !strings.HasPrefix(rt.failures[3], "invalid LogMessageMatch") ||
+		!strings.Contains(rt.failures[3], "func(int) bool cannot compare any slog.Kind") {
+		t.Fatalf("match not validated: %s", rt.failures[3])
+	}
+
+	handler.AssertPrecise(Msg("processed").Attr("count", 5))
+}